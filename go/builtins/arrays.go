@@ -0,0 +1,69 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Unwrap the specified value, which must be an array
+func unwrapArray(v any) ([]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_ARRAY {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as an array", string(t), value))
+	}
+	return value.([]any), nil
+}
+
+// Unwrap the specified count, which must be an integer of at least `min`
+func unwrapCount(v any, min int) (int, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return 0, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return 0, err
+	}
+	if n, ok := value.(float64); ok && n == math.Trunc(n) && n >= float64(min) && n <= math.MaxInt32 {
+		return int(n), nil
+	}
+	return 0, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a count", string(t), value))
+}
+
+// Unwrap the specified index, which must be an integer.
+// Negative indices are relative to the end of an array with the specified length.
+// The result is clamped to the bounds of the array.
+func unwrapIndex(v any, length int) (int, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return 0, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return 0, err
+	}
+	n, ok := value.(float64)
+	if !ok || n != math.Trunc(n) {
+		return 0, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as an index", string(t), value))
+	}
+	if n < 0 {
+		n += float64(length)
+	}
+	return int(max(0, min(n, float64(length)))), nil
+}
+
+// Alter the specified array value using the specified function
+func alterArray(value any, alter func(value []any) []any) (any, jpl.JPLError) {
+	return library.AlterValue(value, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+		return alter(value.([]any)), nil
+	}))
+}
@@ -0,0 +1,48 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Unwrap the specified number, which is to be converted to a string
+func unwrapFormattedNumber(v any) (float64, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return 0, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return 0, err
+	}
+	if t != jpl.JPLT_NUMBER {
+		return 0, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be formatted as a number", string(t), value))
+	}
+	return value.(float64), nil
+}
+
+// Unwrap the specified number of digits, which must be an integer between the specified minimum and 100.
+// If the number of digits is null, the specified fallback is returned.
+func unwrapDigits(v any, min int, fallback int) (int, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return 0, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return 0, err
+	}
+	if t == jpl.JPLT_NULL {
+		return fallback, nil
+	}
+	n, ok := value.(float64)
+	if !ok || n != math.Trunc(n) {
+		return 0, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a number of digits", string(t), value))
+	}
+	if n < float64(min) || n > 100 {
+		return 0, library.ThrowAny(library.NewRuntimeError("number of digits (%*<100v) must be between %d and 100", value, float64(min)))
+	}
+	return int(n), nil
+}
@@ -0,0 +1,227 @@
+package builtins
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcApplyPatch jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := library.UnwrapValue(arg0)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_ARRAY {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a JSON patch", string(t), value))
+	}
+
+	result := input
+	for i, operation := range value.([]any) {
+		if err := signal.CheckHealth(); err != nil {
+			return nil, err
+		}
+
+		var message string
+		if result, message, err = applyPatchOperation(runtime, signal, result, operation); err != nil {
+			return nil, err
+		}
+		if message != "" {
+			return nil, library.ThrowAny(library.NewRuntimeError(map[string]any{
+				"message":   message,
+				"index":     float64(i),
+				"operation": operation,
+			}))
+		}
+	}
+	return next.Pipe(result)
+}
+
+// Apply a single JSON patch operation (RFC 6902) to the specified value.
+// If the operation fails, a message describing the problem is returned.
+func applyPatchOperation(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, value any, operation any) (any, string, jpl.JPLError) {
+	o, err := library.UnwrapValue(operation)
+	if err != nil {
+		return nil, "", err
+	}
+	fields, ok := o.(map[string]any)
+	if !ok {
+		return nil, "operation must be an object", nil
+	}
+	op, err := library.UnwrapValue(fields["op"])
+	if err != nil {
+		return nil, "", err
+	}
+
+	path, message, err := patchPointer(value, fields, "path")
+	if err != nil || message != "" {
+		return nil, message, err
+	}
+
+	switch op {
+	case "add":
+		v, ok := fields["value"]
+		if !ok {
+			return nil, "missing value", nil
+		}
+		return patchAdd(runtime, signal, value, path, v)
+
+	case "remove":
+		_, found, err := lookupPath(value, path)
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return nil, "path not found", nil
+		}
+		result, err := library.DeletePaths(runtime, signal, value, [][]any{path})
+		return result, "", err
+
+	case "replace":
+		v, ok := fields["value"]
+		if !ok {
+			return nil, "missing value", nil
+		}
+		_, found, err := lookupPath(value, path)
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return nil, "path not found", nil
+		}
+		result, err := library.SetPath(runtime, signal, value, path, v)
+		return result, "", err
+
+	case "move":
+		from, message, err := patchPointer(value, fields, "from")
+		if err != nil || message != "" {
+			return nil, message, err
+		}
+		v, found, err := lookupPath(value, from)
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return nil, "path not found", nil
+		}
+		if len(from) < len(path) && slices.Equal(from, path[:len(from)]) {
+			return nil, "cannot move a value into one of its children", nil
+		}
+		result, err := library.DeletePaths(runtime, signal, value, [][]any{from})
+		if err != nil {
+			return nil, "", err
+		}
+		// Resolve the target again, as removing the value may have shifted array indices
+		if path, message, err = patchPointer(result, fields, "path"); err != nil || message != "" {
+			return nil, message, err
+		}
+		return patchAdd(runtime, signal, result, path, v)
+
+	case "copy":
+		from, message, err := patchPointer(value, fields, "from")
+		if err != nil || message != "" {
+			return nil, message, err
+		}
+		v, found, err := lookupPath(value, from)
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return nil, "path not found", nil
+		}
+		return patchAdd(runtime, signal, value, path, v)
+
+	case "test":
+		expected, ok := fields["value"]
+		if !ok {
+			return nil, "missing value", nil
+		}
+		v, found, err := lookupPath(value, path)
+		if err != nil {
+			return nil, "", err
+		}
+		if !found {
+			return nil, "path not found", nil
+		}
+		equal, err := library.Equals(v, expected)
+		if err != nil {
+			return nil, "", err
+		}
+		if !equal {
+			return nil, "test failed", nil
+		}
+		return value, "", nil
+
+	default:
+		return nil, "unknown operation", nil
+	}
+}
+
+// Resolve the JSON pointer in the specified field of a JSON patch operation
+func patchPointer(value any, fields map[string]any, field string) ([]any, string, jpl.JPLError) {
+	pointer, err := library.UnwrapValue(fields[field])
+	if err != nil {
+		return nil, "", err
+	}
+	p, ok := pointer.(string)
+	if !ok {
+		return nil, "missing " + field, nil
+	}
+	tokens, ok := parsePointer(p)
+	if !ok {
+		return nil, "invalid JSON pointer in " + field, nil
+	}
+	return pointerPath(value, tokens)
+}
+
+// Add the specified value at the specified path.
+// Array items are inserted, while object fields are replaced.
+func patchAdd(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, value any, path []any, v any) (any, string, jpl.JPLError) {
+	if len(path) == 0 {
+		return v, "", nil
+	}
+
+	parentPath := path[:len(path)-1]
+	parent, found, err := lookupPath(value, parentPath)
+	if err != nil {
+		return nil, "", err
+	}
+	if !found {
+		return nil, "path not found", nil
+	}
+	p, err := library.UnwrapValue(parent)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch p := p.(type) {
+	case map[string]any:
+		result, err := library.SetPath(runtime, signal, value, path, v)
+		return result, "", err
+
+	case []any:
+		i := int(path[len(path)-1].(float64))
+		if i > len(p) {
+			return nil, "array index out of bounds", nil
+		}
+		altered, err := library.AlterValue(parent, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+			return slices.Insert(slices.Clip(value.([]any)), i, v), nil
+		}))
+		if err != nil {
+			return nil, "", err
+		}
+		result, err := library.SetPath(runtime, signal, value, parentPath, altered)
+		return result, "", err
+
+	default:
+		return nil, "path not found", nil
+	}
+}
@@ -0,0 +1,26 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcChunk jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	n, err := unwrapCount(arg0, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]any, 0, (len(value)+n-1)/n)
+	for i := 0; i < len(value); i += n {
+		result = append(result, value[i:min(i+n, len(value)):min(i+n, len(value))])
+	}
+	return next.Pipe(result)
+}
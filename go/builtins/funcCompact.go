@@ -0,0 +1,37 @@
+package builtins
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcCompact jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	keep := make([]bool, len(value))
+	for i, item := range value {
+		v, err := library.UnwrapValue(item)
+		if err != nil {
+			return nil, err
+		}
+		keep[i] = v != nil
+	}
+
+	result, err := alterArray(input, func(value []any) []any {
+		result := make([]any, 0, len(value))
+		for i, item := range value {
+			if keep[i] {
+				result = append(result, item)
+			}
+		}
+		return slices.Clip(result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
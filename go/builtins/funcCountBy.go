@@ -0,0 +1,37 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcCountBy jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	f, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+	for _, item := range value {
+		results, err := callFunction(runtime, signal, f, item)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			key, err := unwrapKey(r)
+			if err != nil {
+				return nil, err
+			}
+			count, _ := result[key].(float64)
+			result[key] = count + 1
+		}
+	}
+	return next.Pipe(result)
+}
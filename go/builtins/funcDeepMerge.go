@@ -0,0 +1,103 @@
+package builtins
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcDeepMerge jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	strategy, err := library.UnwrapValue(arg1)
+	if err != nil {
+		return nil, err
+	}
+	switch strategy {
+	case nil:
+		strategy = "replace"
+	case "replace", "concat", "index":
+	default:
+		return nil, library.ThrowAny(library.NewRuntimeError("invalid array strategy (%*<100v), expected \"replace\", \"concat\" or \"index\"", strategy))
+	}
+
+	result, err := deepMerge(signal, input, arg0, strategy.(string))
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
+
+// Merge value `b` into value `a` recursively.
+//
+// Objects are merged by key, while arrays are merged according to the specified strategy:
+// `replace` uses `b`, `concat` appends all items of `b` to `a` and `index` merges items at the same index.
+// All other values are replaced by `b`.
+func deepMerge(signal jpl.JPLRuntimeSignal, a, b any, strategy string) (any, jpl.JPLError) {
+	if err := signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	ua, err := library.UnwrapValue(a)
+	if err != nil {
+		return nil, err
+	}
+	ub, err := library.UnwrapValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch va := ua.(type) {
+	case map[string]any:
+		vb, ok := ub.(map[string]any)
+		if !ok {
+			break
+		}
+		changes := make([]*library.ObjectEntry[any], 0, len(vb))
+		for _, key := range sortedKeys(vb) {
+			value := vb[key]
+			if current, ok := va[key]; ok {
+				if value, err = deepMerge(signal, current, value, strategy); err != nil {
+					return nil, err
+				}
+			}
+			changes = append(changes, &library.ObjectEntry[any]{Key: key, Value: value})
+		}
+		return library.AlterValue(a, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+			return library.ApplyObject(value.(map[string]any), changes), nil
+		}))
+
+	case []any:
+		vb, ok := ub.([]any)
+		if !ok || strategy == "replace" {
+			break
+		}
+		if strategy == "concat" {
+			return library.AlterValue(a, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+				return append(slices.Clip(value.([]any)), vb...), nil
+			}))
+		}
+		changes := make([]*library.ArrayEntry[any], len(vb))
+		for i, value := range vb {
+			if i < len(va) {
+				if value, err = deepMerge(signal, va[i], value, strategy); err != nil {
+					return nil, err
+				}
+			}
+			changes[i] = &library.ArrayEntry[any]{Index: i, Value: value}
+		}
+		return library.AlterValue(a, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+			return library.ApplyArray(value.([]any), changes, nil), nil
+		}))
+
+	default:
+	}
+
+	return b, nil
+}
@@ -0,0 +1,23 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcDeletePaths jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	paths, err := unwrapPaths(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := library.DeletePaths(runtime, signal, input, paths)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
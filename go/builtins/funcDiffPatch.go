@@ -0,0 +1,102 @@
+package builtins
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcDiffPatch jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+
+	result, err := diffPatch(signal, input, arg0, nil, []any{})
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
+
+// Append the JSON patch operations (RFC 6902) that transform value `a` at the specified path into value `b`.
+//
+// Object fields are compared by key and array items are compared by index.
+func diffPatch(signal jpl.JPLRuntimeSignal, a, b any, path []any, operations []any) ([]any, jpl.JPLError) {
+	if err := signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	equal, err := library.Equals(a, b)
+	if err != nil {
+		return nil, err
+	}
+	if equal {
+		return operations, nil
+	}
+
+	va, err := library.UnwrapValue(a)
+	if err != nil {
+		return nil, err
+	}
+	vb, err := library.UnwrapValue(b)
+	if err != nil {
+		return nil, err
+	}
+
+	switch va := va.(type) {
+	case map[string]any:
+		if vb, ok := vb.(map[string]any); ok {
+			keys := make([]string, 0, len(va)+len(vb))
+			for key := range va {
+				keys = append(keys, key)
+			}
+			for key := range vb {
+				if _, ok := va[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+			slices.Sort(keys)
+
+			for _, key := range keys {
+				p := append(slices.Clip(path), key)
+				fa, inA := va[key]
+				fb, inB := vb[key]
+				switch {
+				case !inB:
+					operations = append(operations, map[string]any{"op": "remove", "path": formatPointer(p)})
+				case !inA:
+					operations = append(operations, map[string]any{"op": "add", "path": formatPointer(p), "value": fb})
+				default:
+					if operations, err = diffPatch(signal, fa, fb, p, operations); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return operations, nil
+		}
+
+	case []any:
+		if vb, ok := vb.([]any); ok {
+			n := min(len(va), len(vb))
+			for i := 0; i < n; i += 1 {
+				if operations, err = diffPatch(signal, va[i], vb[i], append(slices.Clip(path), float64(i)), operations); err != nil {
+					return nil, err
+				}
+			}
+			for i := n; i < len(vb); i += 1 {
+				operations = append(operations, map[string]any{"op": "add", "path": formatPointer(append(slices.Clip(path), float64(i))), "value": vb[i]})
+			}
+			// Remove surplus items from the end, so that the indices of the remaining items do not shift
+			for i := len(va) - 1; i >= n; i -= 1 {
+				operations = append(operations, map[string]any{"op": "remove", "path": formatPointer(append(slices.Clip(path), float64(i)))})
+			}
+			return operations, nil
+		}
+
+	default:
+	}
+
+	return append(operations, map[string]any{"op": "replace", "path": formatPointer(path), "value": b}), nil
+}
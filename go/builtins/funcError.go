@@ -6,5 +6,5 @@ import (
 )
 
 var funcError jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
-	return nil, library.ThrowAny(library.NewRuntimeError(input))
+	return nil, library.ThrowAny(library.NewUserError(input))
 }
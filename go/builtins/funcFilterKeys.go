@@ -0,0 +1,48 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcFilterKeys jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	f, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+	value, err := unwrapObject(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []*library.ObjectEntry[any]
+	for _, key := range sortedKeys(value) {
+		results, err := callFunction(runtime, signal, f, key, value[key])
+		if err != nil {
+			return nil, err
+		}
+		keep := false
+		for _, result := range results {
+			if keep, err = library.Truthy(result); err != nil {
+				return nil, err
+			} else if keep {
+				break
+			}
+		}
+		if !keep {
+			changes = append(changes, &library.ObjectEntry[any]{Key: key, NoValue: true})
+		}
+	}
+
+	result, err := library.AlterValue(input, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+		return library.ApplyObject(value.(map[string]any), changes), nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
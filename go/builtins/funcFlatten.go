@@ -0,0 +1,57 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcFlatten jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	depth := -1
+	if arg0 != nil {
+		if depth, err = unwrapCount(arg0, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := flatten(signal, value, depth, nil)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
+
+// Append all items of the specified array to `result`, flattening nested arrays up to the specified depth.
+// A negative depth flattens all nested arrays.
+func flatten(signal jpl.JPLRuntimeSignal, value []any, depth int, result []any) ([]any, jpl.JPLError) {
+	if err := signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	for _, item := range value {
+		if depth != 0 {
+			v, err := library.UnwrapValue(item)
+			if err != nil {
+				return nil, err
+			}
+			if items, ok := v.([]any); ok {
+				if result, err = flatten(signal, items, depth-1, result); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+		result = append(result, item)
+	}
+	if result == nil {
+		return []any{}, nil
+	}
+	return result, nil
+}
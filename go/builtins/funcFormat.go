@@ -0,0 +1,32 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcFormat jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	var replacements []any
+	if len(args) > 0 {
+		arg0 = args[0]
+		replacements = args[1:]
+	}
+	tmpl, err := library.UnwrapValue(arg0)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_STRING {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a template", string(t), tmpl))
+	}
+
+	result, err := library.FormatTemplate(tmpl, replacements...)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
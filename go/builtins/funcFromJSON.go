@@ -1,13 +1,15 @@
 package builtins
 
 import (
-	"encoding/json"
-
 	"github.com/jplorg/jpl/go/jpl"
 	"github.com/jplorg/jpl/go/library"
 )
 
 var funcFromJSON jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
 	value, err := library.UnwrapValue(input)
 	if err != nil {
 		return nil, err
@@ -16,18 +18,48 @@ var funcFromJSON jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntim
 	if err != nil {
 		return nil, err
 	}
+	if t != jpl.JPLT_STRING {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be parsed as JSON", string(t), value))
+	}
+	multiple, json5, err := unwrapJSONParseOptions(arg0)
+	if err != nil {
+		return nil, err
+	}
 
-	switch t {
-	case jpl.JPLT_STRING:
-		var result any
-		err := json.Unmarshal([]byte(value.(string)), &result)
-		if err != nil {
-			return nil, library.ThrowAny(library.NewRuntimeError(err.Error()))
-		}
-		return next.Pipe(result)
+	p := &jsonParser{signal: signal, text: []rune(value.(string)), json5: json5}
+	results, syntaxErr := p.parseDocuments(multiple)
+	if syntaxErr != nil {
+		return nil, p.error(syntaxErr)
+	}
+	return library.MuxAll([][]any{results}, library.NewPiperMuxer(next))
+}
 
-	default:
+// Unwrap the specified JSON parse options, which must be an object or null.
+// The results specify whether multiple documents and JSON5 syntax are accepted.
+func unwrapJSONParseOptions(v any) (multiple bool, json5 bool, err jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return false, false, err
 	}
+	t, err := library.Type(value)
+	if err != nil {
+		return false, false, err
+	}
+	if t == jpl.JPLT_NULL {
+		return false, false, nil
+	}
+	if t != jpl.JPLT_OBJECT {
+		return false, false, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as JSON options", string(t), value))
+	}
+	fields := value.(map[string]any)
 
-	return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be parsed as JSON", string(t), value))
+	m, err := library.UnwrapValue(fields["multiple"])
+	if err != nil {
+		return false, false, err
+	}
+	j, err := library.UnwrapValue(fields["json5"])
+	if err != nil {
+		return false, false, err
+	}
+	return m == true, j == true, nil
 }
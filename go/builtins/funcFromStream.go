@@ -0,0 +1,65 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcFromStream jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	f, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+	events, err := callFunction(runtime, signal, f, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []any
+	var current any
+	for _, event := range events {
+		path, leaf, hasLeaf, err := unwrapStreamEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		if hasLeaf {
+			if current, err = library.SetPath(runtime, signal, current, path, leaf); err != nil {
+				return nil, err
+			}
+		}
+		// A value is complete if it is a top-level leaf or if its last top-level child has been closed
+		if (hasLeaf && len(path) == 0) || (!hasLeaf && len(path) == 1) {
+			results = append(results, current)
+			current = nil
+		}
+	}
+	return library.MuxAll([][]any{results}, library.NewPiperMuxer(next))
+}
+
+// Unwrap the specified stream event, which must be either `[path, leaf]` or `[path]`
+func unwrapStreamEvent(v any) ([]any, any, bool, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	event, _ := value.([]any)
+	if len(event) != 1 && len(event) != 2 {
+		return nil, nil, false, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a stream event", string(t), value))
+	}
+	path, err := unwrapPath(event[0])
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if len(event) == 2 {
+		return path, event[1], true, nil
+	}
+	return path, nil, false, nil
+}
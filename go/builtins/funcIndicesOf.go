@@ -0,0 +1,29 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcIndicesOf jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []any{}
+	for i, item := range value {
+		equal, err := library.Equals(item, arg0)
+		if err != nil {
+			return nil, err
+		}
+		if equal {
+			result = append(result, float64(i))
+		}
+	}
+	return next.Pipe(result)
+}
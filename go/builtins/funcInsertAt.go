@@ -0,0 +1,33 @@
+package builtins
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcInsertAt jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	i, err := unwrapIndex(arg0, len(value))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := alterArray(input, func(value []any) []any {
+		return slices.Insert(slices.Clip(value), i, arg1)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,28 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcInvert jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	value, err := unwrapObject(input)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*library.ObjectEntry[any], 0, len(value))
+	for _, key := range sortedKeys(value) {
+		k, err := unwrapKey(value[key])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &library.ObjectEntry[any]{Key: k, Value: key})
+	}
+
+	result, err := replaceEntries(input, entries)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,85 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcJoinBy jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1, arg2, arg3 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	if len(args) > 2 {
+		arg2 = args[2]
+	}
+	if len(args) > 3 {
+		arg3 = args[3]
+	}
+	left, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	right, err := unwrapArray(arg0)
+	if err != nil {
+		return nil, err
+	}
+	leftKey, err := unwrapFunction(arg1)
+	if err != nil {
+		return nil, err
+	}
+	rightKey := leftKey
+	if arg2 != nil {
+		if rightKey, err = unwrapFunction(arg2); err != nil {
+			return nil, err
+		}
+	}
+	mode, err := library.UnwrapValue(arg3)
+	if err != nil {
+		return nil, err
+	}
+	switch mode {
+	case nil:
+		mode = "inner"
+	case "inner", "left", "full":
+	default:
+		return nil, library.ThrowAny(library.NewRuntimeError("invalid join mode (%*<100v), expected \"inner\", \"left\" or \"full\"", mode))
+	}
+
+	kl, err := hashKeys(runtime, signal, left, leftKey)
+	if err != nil {
+		return nil, err
+	}
+	kr, err := hashKeys(runtime, signal, right, rightKey)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string][]int, len(right))
+	for i, key := range kr {
+		index[key] = append(index[key], i)
+	}
+	matched := make([]bool, len(right))
+	result := []any{}
+	for i, item := range left {
+		matches := index[kl[i]]
+		for _, j := range matches {
+			matched[j] = true
+			result = append(result, []any{item, right[j]})
+		}
+		if len(matches) == 0 && mode != "inner" {
+			result = append(result, []any{item, nil})
+		}
+	}
+	if mode == "full" {
+		for j, item := range right {
+			if !matched[j] {
+				result = append(result, []any{nil, item})
+			}
+		}
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,42 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcMapKeys jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	f, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+	value, err := unwrapObject(input)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*library.ObjectEntry[any], 0, len(value))
+	for _, key := range sortedKeys(value) {
+		results, err := callFunction(runtime, signal, f, key, value[key])
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			k, err := unwrapKey(result)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, &library.ObjectEntry[any]{Key: k, Value: value[key]})
+		}
+	}
+
+	result, err := replaceEntries(input, entries)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
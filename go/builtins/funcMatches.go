@@ -0,0 +1,23 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcMatches jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	regex, err := unwrapRegex(arg0)
+	if err != nil {
+		return nil, err
+	}
+	value, err := unwrapSubject(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return library.MuxAll([][]any{regex.FindMatches(value, regex.Global)}, library.NewPiperMuxer(next))
+}
@@ -0,0 +1,65 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcMergePatch jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+
+	result, err := mergePatch(signal, input, arg0)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
+
+// Apply the specified JSON merge patch (RFC 7386) to the specified value
+func mergePatch(signal jpl.JPLRuntimeSignal, value any, patch any) (any, jpl.JPLError) {
+	if err := signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	p, err := library.UnwrapValue(patch)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := p.(map[string]any)
+	if !ok {
+		return patch, nil
+	}
+
+	v, err := library.UnwrapValue(value)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := v.(map[string]any)
+	if !ok {
+		value = map[string]any{}
+	}
+
+	changes := make([]*library.ObjectEntry[any], 0, len(fields))
+	for key, field := range fields {
+		f, err := library.UnwrapValue(field)
+		if err != nil {
+			return nil, err
+		}
+		if f == nil {
+			changes = append(changes, &library.ObjectEntry[any]{Key: key, NoValue: true})
+			continue
+		}
+		merged, err := mergePatch(signal, target[key], field)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, &library.ObjectEntry[any]{Key: key, Value: merged})
+	}
+
+	return library.AlterValue(value, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+		return library.ApplyObject(value.(map[string]any), changes), nil
+	}))
+}
@@ -0,0 +1,112 @@
+package builtins
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcParseNumber jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := library.UnwrapValue(input)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_STRING {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be parsed as a number", string(t), value))
+	}
+	thousands, decimal, err := unwrapNumberSeparators(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern := `^([+-]?)(\d*)`
+	if thousands != "" {
+		pattern = `^([+-]?)(\d{1,3}(?:` + regexp.QuoteMeta(thousands) + `\d{3})+|\d*)`
+	}
+	pattern += `(?:` + regexp.QuoteMeta(decimal) + `(\d*))?(?:[eE]([+-]?\d+))?$`
+	// Patterns are cached, as most calls use the same separators
+	regex, err2 := library.CompileRegex(pattern, "")
+	if err2 != nil {
+		return nil, library.ThrowAny(library.NewRuntimeError(err2.Error()))
+	}
+	m := regex.Regexp.FindStringSubmatch(value.(string))
+	if m == nil || m[2] == "" && m[3] == "" {
+		return nil, library.ThrowAny(library.NewTypeConversionError("%s (%*<100v) does not contain a valid number", string(t), value))
+	}
+	normalized := m[1] + "0" + strings.ReplaceAll(m[2], thousands, "") + "." + m[3] + "0"
+	if m[4] != "" {
+		normalized += "e" + m[4]
+	}
+	parsed, _ := strconv.ParseFloat(normalized, 64)
+	if math.IsInf(parsed, 0) {
+		return nil, library.ThrowAny(library.NewTypeConversionError("%s (%*<100v) does not contain a valid number", string(t), value))
+	}
+	// Adding zero normalizes negative zero
+	result, err := library.NormalizeValue(parsed + 0)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
+
+// Unwrap the thousands separator and the decimal separator from the specified options, which must be an object or null.
+// By default, numbers are formatted like `1,234.5`. An empty thousands separator disallows grouping digits.
+func unwrapNumberSeparators(v any) (thousands string, decimal string, err jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return "", "", err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return "", "", err
+	}
+	if t == jpl.JPLT_NULL {
+		return ",", ".", nil
+	}
+	if t != jpl.JPLT_OBJECT {
+		return "", "", library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as number options", string(t), value))
+	}
+	fields := value.(map[string]any)
+
+	unwrapSeparator := func(key string, fallback string) (string, jpl.JPLError) {
+		s, err := library.UnwrapValue(fields[key])
+		if err != nil {
+			return "", err
+		}
+		st, err := library.Type(s)
+		if err != nil {
+			return "", err
+		}
+		switch st {
+		case jpl.JPLT_NULL:
+			return fallback, nil
+		case jpl.JPLT_STRING:
+			return s.(string), nil
+		default:
+			return "", library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a separator", string(st), s))
+		}
+	}
+	if thousands, err = unwrapSeparator("thousandsSeparator", ","); err != nil {
+		return "", "", err
+	}
+	if decimal, err = unwrapSeparator("decimalSeparator", "."); err != nil {
+		return "", "", err
+	}
+	// Separators must not be ambiguous
+	if decimal == "" || strings.ContainsAny(thousands+decimal, "0123456789+-eE") || strings.Contains(thousands, decimal) || thousands != "" && strings.Contains(decimal, thousands) {
+		return "", "", library.ThrowAny(library.NewRuntimeError("invalid number separators (%*<100v) and (%*<100v)", thousands, decimal))
+	}
+	return thousands, decimal, nil
+}
@@ -0,0 +1,43 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcPartition jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	f, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	matching, nonMatching := []any{}, []any{}
+	for _, item := range value {
+		results, err := callFunction(runtime, signal, f, item)
+		if err != nil {
+			return nil, err
+		}
+		match := false
+		for _, result := range results {
+			if match, err = library.Truthy(result); err != nil {
+				return nil, err
+			} else if match {
+				break
+			}
+		}
+		if match {
+			matching = append(matching, item)
+		} else {
+			nonMatching = append(nonMatching, item)
+		}
+	}
+	return next.Pipe([]any{matching, nonMatching})
+}
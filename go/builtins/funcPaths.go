@@ -0,0 +1,43 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcPaths jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var filter jpl.JPLFunc
+	if len(args) > 0 && args[0] != nil {
+		var err jpl.JPLError
+		filter, err = unwrapFunction(args[0])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var paths []any
+	err := walkPaths(signal, input, nil, func(path []any, value any) jpl.JPLError {
+		if filter == nil {
+			paths = append(paths, path)
+			return nil
+		}
+		results, err := callFunction(runtime, signal, filter, value)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			truthy, err := library.Truthy(result)
+			if err != nil {
+				return err
+			}
+			if truthy {
+				paths = append(paths, path)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return library.MuxAll([][]any{paths}, library.NewPiperMuxer(next))
+}
@@ -0,0 +1,18 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcRecurseWithPath jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	entries := []any{[]any{[]any{}, input}}
+	err := walkPaths(signal, input, nil, func(path []any, value any) jpl.JPLError {
+		entries = append(entries, []any{path, value})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return library.MuxAll([][]any{entries}, library.NewPiperMuxer(next))
+}
@@ -0,0 +1,52 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcRenameKeys jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	m, err := library.UnwrapValue(arg0)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(m)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_OBJECT {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a mapping of keys", string(t), m))
+	}
+	mapping := m.(map[string]any)
+	value, err := unwrapObject(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove all renamed fields first, so that fields can be swapped and renamed fields take precedence
+	var removals, renames []*library.ObjectEntry[any]
+	for _, key := range sortedKeys(mapping) {
+		field, ok := value[key]
+		if !ok {
+			continue
+		}
+		k, err := unwrapKey(mapping[key])
+		if err != nil {
+			return nil, err
+		}
+		removals = append(removals, &library.ObjectEntry[any]{Key: key, NoValue: true})
+		renames = append(renames, &library.ObjectEntry[any]{Key: k, Value: field})
+	}
+
+	result, err := library.AlterValue(input, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+		return library.ApplyObject(value.(map[string]any), append(removals, renames...)), nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,84 @@
+package builtins
+
+import (
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcReplace jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	regex, err := unwrapRegex(arg0)
+	if err != nil {
+		return nil, err
+	}
+	value, err := unwrapSubject(input)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := library.UnwrapValue(arg1)
+	if err != nil {
+		return nil, err
+	}
+	tr, err := library.Type(replacement)
+	if err != nil {
+		return nil, err
+	}
+	if tr != jpl.JPLT_STRING && tr != jpl.JPLT_FUNCTION {
+		return nil, library.ThrowAny(library.NewTypeError("cannot use %s (%*<100v) as replacement", string(tr), replacement))
+	}
+
+	// Every match is replaced by each of its replacements, which results in all combinations of replacements
+	matches := regex.FindMatches(value, regex.Global)
+	items := make([]string, len(matches))
+	replacements := make([][]string, len(matches))
+	for i, match := range matches {
+		items[i] = match.(map[string]any)["string"].(string)
+		if tr == jpl.JPLT_STRING {
+			replacements[i] = []string{replacement.(string)}
+			continue
+		}
+
+		results, err := callFunction(runtime, signal, replacement.(jpl.JPLFunc), match)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			r, err := library.UnwrapValue(result)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := r.(string)
+			if !ok {
+				t, err := library.Type(r)
+				if err != nil {
+					return nil, err
+				}
+				return nil, library.ThrowAny(library.NewTypeError("cannot use %s (%*<100v) as replacement", string(t), r))
+			}
+			replacements[i] = append(replacements[i], s)
+		}
+	}
+
+	chars := []rune(value)
+	return library.MuxAll([][][]string{library.ApplyCombinations(items, replacements)}, jpl.IOMuxerFunc[[]string, []any](func(args ...[]string) ([]any, jpl.JPLError) {
+		var result strings.Builder
+		from := 0
+		for i, match := range matches {
+			m := match.(map[string]any)
+			offset := int(m["offset"].(float64))
+			result.WriteString(string(chars[from:offset]))
+			result.WriteString(args[0][i])
+			from = offset + int(m["length"].(float64))
+		}
+		result.WriteString(string(chars[from:]))
+		return next.Pipe(result.String())
+	}))
+}
@@ -0,0 +1,26 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcRethrow jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	err, errInfo := library.ErrorFromInfo(input)
+	if errInfo != nil {
+		return nil, errInfo
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value, errInfo := library.UnwrapValue(input)
+	if errInfo != nil {
+		return nil, errInfo
+	}
+	t, errInfo := library.Type(value)
+	if errInfo != nil {
+		return nil, errInfo
+	}
+	return nil, library.ThrowAny(library.NewTypeError("cannot rethrow %s (%*<100v) as it does not describe an error", string(t), value))
+}
@@ -0,0 +1,32 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcScan jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1, arg2 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	if len(args) > 2 {
+		arg2 = args[2]
+	}
+	gen, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+	f, err := unwrapFunction(arg1)
+	if err != nil {
+		return nil, err
+	}
+
+	_, results, err := foldGenerator(runtime, signal, gen, f, input, arg2, next.Pipe)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,26 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcSetPath jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	path, err := unwrapPath(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := library.SetPath(runtime, signal, input, path, arg1)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,63 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcSortWith jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	cmp, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := library.CopySlice(value)
+	err = sortStable(result, func(a, b any) (int, jpl.JPLError) {
+		if err := signal.CheckHealth(); err != nil {
+			return 0, err
+		}
+		results, err := callFunction(runtime, signal, cmp, nil, a, b)
+		if err != nil {
+			return 0, err
+		}
+		var r any
+		if len(results) > 0 {
+			r = results[0]
+		}
+		return unwrapComparison(r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
+
+// Unwrap the specified comparison result, which must be a number
+func unwrapComparison(v any) (int, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return 0, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return 0, err
+	}
+	if t != jpl.JPLT_NUMBER {
+		return 0, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a comparison result", string(t), value))
+	}
+	n := value.(float64)
+	if n < 0 {
+		return -1, nil
+	} else if n > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,74 @@
+package builtins
+
+import (
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcSplit jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := library.UnwrapValue(input)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	separator, err := library.UnwrapValue(arg0)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := library.Type(separator)
+	if err != nil {
+		return nil, err
+	}
+
+	if t == jpl.JPLT_STRING {
+		v := value.(string)
+		switch ts {
+		case jpl.JPLT_STRING:
+			return next.Pipe(toAnySlice(strings.Split(v, separator.(string))))
+
+		case jpl.JPLT_OBJECT:
+			regex, err := unwrapRegex(arg0)
+			if err != nil {
+				return nil, err
+			}
+
+			// Empty matches are ignored
+			chars := []rune(v)
+			var parts []string
+			from := 0
+			for _, match := range regex.FindMatches(v, true) {
+				m := match.(map[string]any)
+				length := int(m["length"].(float64))
+				if length == 0 {
+					continue
+				}
+				offset := int(m["offset"].(float64))
+				parts = append(parts, string(chars[from:offset]))
+				from = offset + length
+			}
+			parts = append(parts, string(chars[from:]))
+			return next.Pipe(toAnySlice(parts))
+
+		default:
+		}
+	}
+
+	return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be split by %s (%*<100v)", string(t), value, string(ts), separator))
+}
+
+func toAnySlice(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
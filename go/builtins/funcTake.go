@@ -0,0 +1,27 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcTake jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if _, err := unwrapArray(input); err != nil {
+		return nil, err
+	}
+	n, err := unwrapCount(arg0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := alterArray(input, func(value []any) []any {
+		return value[:min(n, len(value)):min(n, len(value))]
+	})
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result)
+}
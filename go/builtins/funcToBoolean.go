@@ -0,0 +1,41 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcToBoolean jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	value, err := library.UnwrapValue(input)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case jpl.JPLT_NULL:
+		return next.Pipe(false)
+
+	case jpl.JPLT_BOOLEAN:
+		return next.Pipe(value)
+
+	case jpl.JPLT_NUMBER:
+		return next.Pipe(value.(float64) != 0)
+
+	case jpl.JPLT_STRING:
+		switch value {
+		case "true":
+			return next.Pipe(true)
+		case "false":
+			return next.Pipe(false)
+		}
+		return nil, library.ThrowAny(library.NewTypeConversionError("%s (%*<100v) does not contain a valid boolean", string(t), value))
+
+	default:
+	}
+
+	return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be converted to a boolean", string(t), value))
+}
@@ -0,0 +1,25 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcToExponential jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	n, err := unwrapFormattedNumber(input)
+	if err != nil {
+		return nil, err
+	}
+	digits, err := unwrapDigits(arg0, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	if digits < 0 {
+		return next.Pipe(library.FormatShortestExponential(n))
+	}
+	return next.Pipe(library.FormatExponential(n, digits))
+}
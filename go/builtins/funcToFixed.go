@@ -0,0 +1,33 @@
+package builtins
+
+import (
+	"math"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcToFixed jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	n, err := unwrapFormattedNumber(input)
+	if err != nil {
+		return nil, err
+	}
+	digits, err := unwrapDigits(arg0, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like JavaScript's `Number.prototype.toFixed`, large numbers are not formatted in fixed-point notation
+	if math.Abs(n) >= 1e21 {
+		result, err := library.StrictDisplayValue(n)
+		if err != nil {
+			return nil, err
+		}
+		return next.Pipe(result)
+	}
+	return next.Pipe(library.FormatFixed(n, digits))
+}
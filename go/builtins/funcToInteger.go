@@ -0,0 +1,77 @@
+package builtins
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcToInteger jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	value, err := library.UnwrapValue(input)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	radix, err := unwrapRadix(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case jpl.JPLT_NUMBER:
+		// Adding zero normalizes negative zero
+		return next.Pipe(math.Trunc(value.(float64)) + 0)
+
+	case jpl.JPLT_STRING:
+		s := value.(string)
+		sign := 1.0
+		if strings.HasPrefix(s, "-") {
+			sign = -1
+			s = s[1:]
+		} else if strings.HasPrefix(s, "+") {
+			s = s[1:]
+		}
+		// The sign has already been consumed, so only digits are accepted
+		n, ok := new(big.Int), s != "" && !strings.ContainsAny(s, "+-")
+		if ok {
+			_, ok = n.SetString(s, radix)
+		}
+		if !ok {
+			return nil, library.ThrowAny(library.NewTypeConversionError("%s (%*<100v) does not contain a valid integer", string(t), value))
+		}
+		result, _ := new(big.Float).SetInt(n).Float64()
+		if math.IsInf(result, 0) {
+			return nil, library.ThrowAny(library.NewTypeConversionError("%s (%*<100v) does not contain a valid integer", string(t), value))
+		}
+		return next.Pipe(sign*result + 0)
+
+	default:
+	}
+
+	return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be converted to an integer", string(t), value))
+}
+
+// Unwrap the specified radix, which must be an integer between 2 and 36, and defaults to 10
+func unwrapRadix(v any) (int, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return 0, err
+	}
+	if value == nil {
+		return 10, nil
+	}
+	if n, ok := value.(float64); ok && n == math.Trunc(n) && n >= 2 && n <= 36 {
+		return int(n), nil
+	}
+	return 0, library.ThrowAny(library.NewRuntimeError("invalid radix (%*<100v), expected an integer between 2 and 36", value))
+}
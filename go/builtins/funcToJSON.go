@@ -1,14 +1,78 @@
 package builtins
 
 import (
+	"strings"
+
 	"github.com/jplorg/jpl/go/jpl"
 	"github.com/jplorg/jpl/go/library"
 )
 
 var funcToJSON jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
-	json, err := library.StringifyJSON(input, false)
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	options, err := unwrapJSONOptions(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	json, err := library.FormatJSON(input, options)
 	if err != nil {
 		return nil, err
 	}
 	return next.Pipe(json)
 }
+
+// Unwrap the specified JSON options, which must be an object or null
+func unwrapJSONOptions(v any) (jpl.JPLJSONOptions, jpl.JPLError) {
+	var options jpl.JPLJSONOptions
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return options, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return options, err
+	}
+	if t == jpl.JPLT_NULL {
+		return options, nil
+	}
+	if t != jpl.JPLT_OBJECT {
+		return options, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as JSON options", string(t), value))
+	}
+	fields := value.(map[string]any)
+
+	indent, err := library.UnwrapValue(fields["indent"])
+	if err != nil {
+		return options, err
+	}
+	// Like JavaScript's `JSON.stringify`, indentation is limited to 10 characters
+	var gap string
+	switch i := indent.(type) {
+	case nil:
+	case string:
+		gap = string([]rune(i)[:min(len([]rune(i)), 10)])
+	default:
+		n, err := unwrapCount(i, 0)
+		if err != nil {
+			return options, err
+		}
+		gap = strings.Repeat(" ", min(n, 10))
+	}
+	options.Indent = &gap
+
+	for key, field := range map[string]**bool{
+		"sortKeys":  &options.SortKeys,
+		"asciiOnly": &options.ASCIIOnly,
+		"canonical": &options.Canonical,
+	} {
+		value, err := library.UnwrapValue(fields[key])
+		if err != nil {
+			return options, err
+		}
+		enabled := value == true
+		*field = &enabled
+	}
+	return options, nil
+}
@@ -0,0 +1,30 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcToPrecision jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	n, err := unwrapFormattedNumber(input)
+	if err != nil {
+		return nil, err
+	}
+	digits, err := unwrapDigits(arg0, 1, -1)
+	if err != nil {
+		return nil, err
+	}
+	if digits < 0 {
+		// Adding zero normalizes negative zero
+		result, err := library.StrictDisplayValue(n + 0)
+		if err != nil {
+			return nil, err
+		}
+		return next.Pipe(result)
+	}
+	return next.Pipe(library.FormatPrecision(n, digits))
+}
@@ -0,0 +1,59 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcToStream jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var events []any
+	if err := streamEvents(signal, input, []any{}, &events); err != nil {
+		return nil, err
+	}
+	return library.MuxAll([][]any{events}, library.NewPiperMuxer(next))
+}
+
+// Append the stream events of the specified value.
+//
+// Leaves, which are scalars or empty arrays and objects, produce an event `[path, leaf]`,
+// while closing a non-empty array or object produces an event `[path]` with the path of its last child.
+func streamEvents(signal jpl.JPLRuntimeSignal, value any, path []any, events *[]any) jpl.JPLError {
+	if err := signal.CheckHealth(); err != nil {
+		return err
+	}
+
+	v, err := library.UnwrapValue(value)
+	if err != nil {
+		return err
+	}
+
+	var keys []any
+	var children []any
+	switch v := v.(type) {
+	case []any:
+		for i, item := range v {
+			keys = append(keys, float64(i))
+			children = append(children, item)
+		}
+
+	case map[string]any:
+		for _, key := range sortedKeys(v) {
+			keys = append(keys, key)
+			children = append(children, v[key])
+		}
+
+	default:
+	}
+
+	if len(keys) == 0 {
+		*events = append(*events, []any{path, value})
+		return nil
+	}
+	for i, key := range keys {
+		if err := streamEvents(signal, children[i], append(library.CopySlice(path), key), events); err != nil {
+			return err
+		}
+	}
+	*events = append(*events, []any{append(library.CopySlice(path), keys[len(keys)-1])})
+	return nil
+}
@@ -0,0 +1,33 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcTranspose jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]any, len(value))
+	l := 0
+	for i, row := range value {
+		if rows[i], err = unwrapArray(row); err != nil {
+			return nil, err
+		}
+		l = max(l, len(rows[i]))
+	}
+
+	// Shorter rows are padded with null
+	result := make([]any, l)
+	for j := range result {
+		column := make([]any, len(rows))
+		for i, row := range rows {
+			if j < len(row) {
+				column[i] = row[j]
+			}
+		}
+		result[j] = column
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,30 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcValidate jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	schema, err := library.StripJSON(arg0)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := library.StripJSON(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := newSchemaValidator(signal, schema).validate(instance, schema, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if result.errors == nil {
+		return next.Pipe([]any{})
+	}
+	return next.Pipe(result.errors)
+}
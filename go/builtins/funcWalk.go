@@ -0,0 +1,74 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var funcWalk jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	f, err := unwrapFunction(arg0)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := walkValue(runtime, signal, f, input)
+	if err != nil {
+		return nil, err
+	}
+	return library.MuxAll([][]any{results}, library.NewPiperMuxer(next))
+}
+
+// Rebuild the specified value by applying `f` to all of its nodes from the leaves up.
+// Arrays and objects are rebuilt by altering the original value, so that custom types can take part in the rebuild.
+//
+// All outputs of `f` on array items are included in the resulting array, while object fields use the first output of `f`
+// and are removed if there is none.
+func walkValue(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, f jpl.JPLFunc, value any) ([]any, jpl.JPLError) {
+	if err := signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	v, err := library.UnwrapValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	node := value
+	switch v := v.(type) {
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			results, err := walkValue(runtime, signal, f, item)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, results...)
+		}
+		if node, err = library.AlterValue(value, jpl.JPLModifierFunc(func(any) (any, jpl.JPLError) { return items, nil })); err != nil {
+			return nil, err
+		}
+
+	case map[string]any:
+		fields := make(map[string]any, len(v))
+		for _, key := range sortedKeys(v) {
+			results, err := walkValue(runtime, signal, f, v[key])
+			if err != nil {
+				return nil, err
+			}
+			if len(results) > 0 {
+				fields[key] = results[0]
+			}
+		}
+		if node, err = library.AlterValue(value, jpl.JPLModifierFunc(func(any) (any, jpl.JPLError) { return fields, nil })); err != nil {
+			return nil, err
+		}
+
+	default:
+	}
+
+	return callFunction(runtime, signal, f, node)
+}
@@ -0,0 +1,35 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcWindow jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	var arg0, arg1 any
+	if len(args) > 0 {
+		arg0 = args[0]
+	}
+	if len(args) > 1 {
+		arg1 = args[1]
+	}
+	value, err := unwrapArray(input)
+	if err != nil {
+		return nil, err
+	}
+	n, err := unwrapCount(arg0, 1)
+	if err != nil {
+		return nil, err
+	}
+	step := 1
+	if arg1 != nil {
+		if step, err = unwrapCount(arg1, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	result := []any{}
+	for i := 0; i+n <= len(value); i += step {
+		result = append(result, value[i:i+n:i+n])
+	}
+	return next.Pipe(result)
+}
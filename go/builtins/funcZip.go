@@ -0,0 +1,29 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var funcZip jpl.JPLFunc = func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	arrays := make([][]any, len(args)+1)
+	for i, v := range append([]any{input}, args...) {
+		var err jpl.JPLError
+		if arrays[i], err = unwrapArray(v); err != nil {
+			return nil, err
+		}
+	}
+
+	l := len(arrays[0])
+	for _, array := range arrays[1:] {
+		l = min(l, len(array))
+	}
+	result := make([]any, l)
+	for i := range result {
+		tuple := make([]any, len(arrays))
+		for j, array := range arrays {
+			tuple[j] = array[i]
+		}
+		result[i] = tuple
+	}
+	return next.Pipe(result)
+}
@@ -0,0 +1,59 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Piper that passes its output through unchanged
+func collect(output any) ([]any, jpl.JPLError) {
+	return []any{output}, nil
+}
+
+// Unwrap the specified function argument
+func unwrapFunction(v any) (jpl.JPLFunc, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_FUNCTION {
+		return nil, library.ThrowAny(library.NewTypeError("cannot execute %s (%*<100v)", string(t), value))
+	}
+	return value.(jpl.JPLFunc), nil
+}
+
+// Call the specified function and collect all of its outputs
+func callFunction(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, fn jpl.JPLFunc, input any, args ...any) ([]any, jpl.JPLError) {
+	results, err := fn(runtime, signal, jpl.JPLPiperFunc(collect), input, args...)
+	if err != nil {
+		return nil, library.AdaptError(err)
+	}
+	return results, nil
+}
+
+// Fold the outputs of gen, which is called with the specified input, one by one without collecting them beforehand.
+// For each output, f is called with the output as its input and the accumulator and index as its arguments.
+// The last output of f becomes the new accumulator, which is passed to emit after each step.
+func foldGenerator(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, gen jpl.JPLFunc, f jpl.JPLFunc, input any, initialValue any, emit func(acc any) ([]any, jpl.JPLError)) (acc any, results []any, err jpl.JPLError) {
+	acc = initialValue
+	index := 0
+	results, errGen := gen(runtime, signal, jpl.JPLPiperFunc(func(output any) ([]any, jpl.JPLError) {
+		outputs, err := callFunction(runtime, signal, f, output, acc, float64(index))
+		if err != nil {
+			return nil, err
+		}
+		index++
+		if len(outputs) > 0 {
+			acc = outputs[len(outputs)-1]
+		}
+		return emit(acc)
+	}), input)
+	if errGen != nil {
+		return nil, nil, library.AdaptError(errGen)
+	}
+	return acc, results, nil
+}
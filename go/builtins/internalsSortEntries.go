@@ -1,40 +1,43 @@
 package builtins
 
 import (
-	"slices"
-
 	"github.com/jplorg/jpl/go/jpl"
 	"github.com/jplorg/jpl/go/library"
 )
 
-func sortFn(a, b any) (int, jpl.JPLError) {
-	ua, err := library.UnwrapValue(a)
-	if err != nil {
-		return 0, err
+var funcSortEntries = library.NativeFunction(func(runtime jpl.JPLRuntime, input any, args ...any) ([]any, error) {
+	var arg0 any
+	if len(args) > 0 {
+		arg0 = args[0]
 	}
-	ub, err := library.UnwrapValue(b)
+	options, err := unwrapSortOptions(arg0)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return library.CompareArrays(ua.([]any)[0], ub.([]any)[0])
-}
 
-var funcSortEntries = library.NativeFunction(func(runtime jpl.JPLRuntime, input any, args ...any) ([]any, error) {
 	alteredValue, err := library.AlterValue(input, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
 		result := library.CopySlice(value.([]any))
-		var sortErr jpl.JPLError
-		slices.SortFunc(result, func(a, b any) int {
-			c, err := sortFn(a, b)
+		err := sortStable(result, func(a, b any) (int, jpl.JPLError) {
+			ua, err := library.UnwrapValue(a)
+			if err != nil {
+				return 0, err
+			}
+			ub, err := library.UnwrapValue(b)
+			if err != nil {
+				return 0, err
+			}
+			ka, err := library.UnwrapValue(ua.([]any)[0])
+			if err != nil {
+				return 0, err
+			}
+			kb, err := library.UnwrapValue(ub.([]any)[0])
 			if err != nil {
-				if sortErr == nil {
-					sortErr = err
-				}
-				return 0
+				return 0, err
 			}
-			return c
+			return options.compareKeys(ka.([]any), kb.([]any))
 		})
-		if sortErr != nil {
-			return nil, sortErr
+		if err != nil {
+			return nil, err
 		}
 		return result, nil
 	}))
@@ -0,0 +1,518 @@
+package builtins
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Parser for JSON documents, which optionally accepts JSON5 syntax.
+// All offsets are counted in unicode code points.
+type jsonParser struct {
+	signal jpl.JPLRuntimeSignal
+	text   []rune
+	pos    int
+	json5  bool
+	depth  int
+}
+
+// Maximum nesting depth of arrays and objects, which is the same as for encoding/json
+const jsonMaxDepth = 10000
+
+// Syntax error at the specified offset of a JSON document
+type jsonSyntaxError struct {
+	message string
+	offset  int
+	// Error that aborted parsing, which is returned as is instead of the syntax error
+	inner jpl.JPLError
+}
+
+func (p *jsonParser) fail(message string) *jsonSyntaxError {
+	return &jsonSyntaxError{message: message, offset: p.pos}
+}
+
+// Check that parsing has not been canceled
+func (p *jsonParser) checkHealth() *jsonSyntaxError {
+	if err := p.signal.CheckHealth(); err != nil {
+		return &jsonSyntaxError{inner: err}
+	}
+	return nil
+}
+
+func (p *jsonParser) unexpected() *jsonSyntaxError {
+	if p.pos >= len(p.text) {
+		return p.fail("unexpected end of input")
+	}
+	c, _ := library.FormatJSON(string(p.text[p.pos]), jpl.JPLJSONOptions{})
+	return p.fail("unexpected character " + c)
+}
+
+// Create a runtime error for the specified syntax error, which contains its message and position.
+// Errors that have aborted parsing are returned as is.
+func (p *jsonParser) error(err *jsonSyntaxError) jpl.JPLError {
+	if err.inner != nil {
+		return err.inner
+	}
+	line, column := 1, 1
+	for i := 0; i < err.offset && i < len(p.text); i += 1 {
+		if c := p.text[i]; c == '\n' || (c == '\r' && (i+1 >= len(p.text) || p.text[i+1] != '\n')) {
+			line += 1
+			column = 1
+		} else {
+			column += 1
+		}
+	}
+	return library.ThrowAny(library.NewRuntimeError(map[string]any{
+		"message": err.message,
+		"offset":  float64(err.offset),
+		"line":    float64(line),
+		"column":  float64(column),
+	}))
+}
+
+func (p *jsonParser) peek(offset int) rune {
+	if p.pos+offset < len(p.text) {
+		return p.text[p.pos+offset]
+	}
+	return -1
+}
+
+// Parse all documents of the text.
+// Unless multiple documents are allowed, the text must contain exactly one document.
+func (p *jsonParser) parseDocuments(multiple bool) ([]any, *jsonSyntaxError) {
+	results := []any{}
+	for {
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.text) && (multiple || len(results) > 0) {
+			return results, nil
+		}
+		if len(results) > 0 && !multiple {
+			return nil, p.unexpected()
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, value)
+	}
+}
+
+func (p *jsonParser) isWhitespace(c rune) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r':
+		return true
+	case '\v', '\f', '\u00a0', '\u2028', '\u2029', '\ufeff':
+		return p.json5
+	default:
+		return p.json5 && unicode.Is(unicode.Zs, c)
+	}
+}
+
+func (p *jsonParser) skipWhitespace() *jsonSyntaxError {
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		switch {
+		case p.isWhitespace(c):
+			p.pos += 1
+
+		case p.json5 && c == '/' && p.peek(1) == '/':
+			for p.pos < len(p.text) && !isLineTerminator(p.text[p.pos]) {
+				p.pos += 1
+			}
+
+		case p.json5 && c == '/' && p.peek(1) == '*':
+			start := p.pos
+			p.pos += 2
+			for !(p.peek(0) == '*' && p.peek(1) == '/') {
+				if p.pos >= len(p.text) {
+					p.pos = start
+					return p.fail("unterminated comment")
+				}
+				p.pos += 1
+			}
+			p.pos += 2
+
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+func isLineTerminator(c rune) bool {
+	return c == '\n' || c == '\r' || c == '\u2028' || c == '\u2029'
+}
+
+func isIdentifierStart(c rune) bool {
+	return c == '_' || c == '$' || unicode.IsLetter(c)
+}
+
+func isIdentifierPart(c rune) bool {
+	return isIdentifierStart(c) || unicode.IsDigit(c)
+}
+
+func (p *jsonParser) parseIdentifier() string {
+	start := p.pos
+	if p.pos < len(p.text) && isIdentifierStart(p.text[p.pos]) {
+		p.pos += 1
+		for p.pos < len(p.text) && isIdentifierPart(p.text[p.pos]) {
+			p.pos += 1
+		}
+	}
+	return string(p.text[start:p.pos])
+}
+
+func (p *jsonParser) parseValue() (any, *jsonSyntaxError) {
+	switch c := p.peek(0); {
+	case c == '{' || c == '[':
+		if p.depth >= jsonMaxDepth {
+			return nil, p.fail("exceeded max depth")
+		}
+		p.depth += 1
+		defer func() { p.depth -= 1 }()
+		if c == '{' {
+			return p.parseObject()
+		}
+		return p.parseArray()
+
+	case c == '"' || (p.json5 && c == '\''):
+		return p.parseString()
+
+	case c == '-' || (c >= '0' && c <= '9') || (p.json5 && (c == '+' || c == '.')):
+		return p.parseNumber()
+
+	default:
+		start := p.pos
+		switch p.parseIdentifier() {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		case "Infinity", "NaN":
+			if p.json5 {
+				p.pos = start
+				return nil, p.fail("number out of range")
+			}
+		}
+		p.pos = start
+		return nil, p.unexpected()
+	}
+}
+
+func (p *jsonParser) parseObject() (any, *jsonSyntaxError) {
+	result := map[string]any{}
+	p.pos += 1
+	if err := p.skipWhitespace(); err != nil {
+		return nil, err
+	}
+	if p.peek(0) == '}' {
+		p.pos += 1
+		return result, nil
+	}
+
+	for {
+		if err := p.checkHealth(); err != nil {
+			return nil, err
+		}
+
+		var key string
+		switch c := p.peek(0); {
+		case c == '"' || (p.json5 && c == '\''):
+			var err *jsonSyntaxError
+			if key, err = p.parseString(); err != nil {
+				return nil, err
+			}
+		case p.json5 && isIdentifierStart(c):
+			key = p.parseIdentifier()
+		default:
+			return nil, p.unexpected()
+		}
+
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
+		if p.peek(0) != ':' {
+			return nil, p.unexpected()
+		}
+		p.pos += 1
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
+		switch p.peek(0) {
+		case ',':
+			p.pos += 1
+			if err := p.skipWhitespace(); err != nil {
+				return nil, err
+			}
+			if p.json5 && p.peek(0) == '}' {
+				p.pos += 1
+				return result, nil
+			}
+		case '}':
+			p.pos += 1
+			return result, nil
+		default:
+			return nil, p.unexpected()
+		}
+	}
+}
+
+func (p *jsonParser) parseArray() (any, *jsonSyntaxError) {
+	result := []any{}
+	p.pos += 1
+	if err := p.skipWhitespace(); err != nil {
+		return nil, err
+	}
+	if p.peek(0) == ']' {
+		p.pos += 1
+		return result, nil
+	}
+
+	for {
+		if err := p.checkHealth(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+
+		if err := p.skipWhitespace(); err != nil {
+			return nil, err
+		}
+		switch p.peek(0) {
+		case ',':
+			p.pos += 1
+			if err := p.skipWhitespace(); err != nil {
+				return nil, err
+			}
+			if p.json5 && p.peek(0) == ']' {
+				p.pos += 1
+				return result, nil
+			}
+		case ']':
+			p.pos += 1
+			return result, nil
+		default:
+			return nil, p.unexpected()
+		}
+	}
+}
+
+func (p *jsonParser) parseString() (string, *jsonSyntaxError) {
+	quote := p.text[p.pos]
+	p.pos += 1
+	var units []uint16
+	var b strings.Builder
+	// Pending UTF-16 code units of unicode escapes are combined, so that escaped surrogate pairs are decoded properly
+	flush := func() {
+		for _, r := range utf16Decode(units) {
+			b.WriteRune(r)
+		}
+		units = nil
+	}
+
+	for {
+		if p.pos >= len(p.text) {
+			return "", p.fail("unterminated string")
+		}
+		c := p.text[p.pos]
+		switch {
+		case c == quote:
+			p.pos += 1
+			flush()
+			return b.String(), nil
+
+		case c == '\\':
+			start := p.pos
+			p.pos += 1
+			e := p.peek(0)
+			p.pos += 1
+			if e == 'u' {
+				unit, ok := p.parseHex(4)
+				if !ok {
+					p.pos = start
+					return "", p.fail("invalid unicode escape sequence")
+				}
+				units = append(units, uint16(unit))
+				continue
+			}
+			flush()
+			switch {
+			case e == '"' || e == '\\' || e == '/':
+				b.WriteRune(e)
+			case e == 'b':
+				b.WriteByte('\b')
+			case e == 'f':
+				b.WriteByte('\f')
+			case e == 'n':
+				b.WriteByte('\n')
+			case e == 'r':
+				b.WriteByte('\r')
+			case e == 't':
+				b.WriteByte('\t')
+			case !p.json5 || e == -1 || (e >= '1' && e <= '9'):
+				p.pos = start
+				return "", p.fail("invalid escape sequence")
+			case e == 'v':
+				b.WriteByte('\v')
+			case e == '0':
+				if d := p.peek(0); d >= '0' && d <= '9' {
+					p.pos = start
+					return "", p.fail("invalid escape sequence")
+				}
+				b.WriteByte(0)
+			case e == 'x':
+				r, ok := p.parseHex(2)
+				if !ok {
+					p.pos = start
+					return "", p.fail("invalid escape sequence")
+				}
+				b.WriteRune(r)
+			case e == '\r':
+				// Line continuations are removed
+				if p.peek(0) == '\n' {
+					p.pos += 1
+				}
+			case isLineTerminator(e):
+			default:
+				b.WriteRune(e)
+			}
+
+		case c < 0x20 && (!p.json5 || c == '\n' || c == '\r'):
+			return "", p.unexpected()
+
+		default:
+			flush()
+			b.WriteRune(c)
+			p.pos += 1
+		}
+	}
+}
+
+// Parse the specified number of hexadecimal digits
+func (p *jsonParser) parseHex(digits int) (rune, bool) {
+	if p.pos+digits > len(p.text) {
+		return 0, false
+	}
+	value, err := strconv.ParseUint(string(p.text[p.pos:p.pos+digits]), 16, 32)
+	if err != nil {
+		return 0, false
+	}
+	p.pos += digits
+	return rune(value), true
+}
+
+// Decode the specified UTF-16 code units, replacing unpaired surrogates with the unicode replacement character
+func utf16Decode(units []uint16) []rune {
+	runes := make([]rune, 0, len(units))
+	for i := 0; i < len(units); i += 1 {
+		u := rune(units[i])
+		if u >= 0xd800 && u < 0xdc00 && i+1 < len(units) && units[i+1] >= 0xdc00 && units[i+1] < 0xe000 {
+			runes = append(runes, 0x10000+(u-0xd800)<<10+(rune(units[i+1])-0xdc00))
+			i += 1
+		} else if u >= 0xd800 && u < 0xe000 {
+			runes = append(runes, unicode.ReplacementChar)
+		} else {
+			runes = append(runes, u)
+		}
+	}
+	return runes
+}
+
+func (p *jsonParser) parseNumber() (any, *jsonSyntaxError) {
+	start := p.pos
+	sign := 1.0
+	switch p.peek(0) {
+	case '-':
+		sign = -1
+		p.pos += 1
+	case '+':
+		p.pos += 1
+	}
+
+	var value float64
+	switch c := p.peek(0); {
+	case p.json5 && isIdentifierStart(c):
+		switch p.parseIdentifier() {
+		case "Infinity", "NaN":
+			p.pos = start
+			return nil, p.fail("number out of range")
+		}
+		p.pos = start + 1
+		return nil, p.unexpected()
+
+	case p.json5 && c == '0' && (p.peek(1) == 'x' || p.peek(1) == 'X'):
+		p.pos += 2
+		digitsStart := p.pos
+		for p.pos < len(p.text) && strings.ContainsRune("0123456789abcdefABCDEF", p.text[p.pos]) {
+			p.pos += 1
+		}
+		if p.pos == digitsStart {
+			return nil, p.unexpected()
+		}
+		n, _ := new(big.Int).SetString(string(p.text[digitsStart:p.pos]), 16)
+		value, _ = new(big.Float).SetInt(n).Float64()
+
+	default:
+		numberStart := p.pos
+		intDigits := p.skipDigits()
+		if intDigits > 1 && p.text[numberStart] == '0' {
+			p.pos = numberStart + 1
+			return nil, p.unexpected()
+		}
+		if intDigits == 0 && !(p.json5 && p.peek(0) == '.') {
+			return nil, p.unexpected()
+		}
+		if p.peek(0) == '.' {
+			p.pos += 1
+			if p.skipDigits() == 0 && !(p.json5 && intDigits > 0) {
+				return nil, p.unexpected()
+			}
+		}
+		if e := p.peek(0); e == 'e' || e == 'E' {
+			p.pos += 1
+			if s := p.peek(0); s == '+' || s == '-' {
+				p.pos += 1
+			}
+			if p.skipDigits() == 0 {
+				return nil, p.unexpected()
+			}
+		}
+		value, _ = strconv.ParseFloat(string(p.text[numberStart:p.pos]), 64)
+	}
+
+	if math.IsInf(value, 0) {
+		p.pos = start
+		return nil, p.fail("number out of range")
+	}
+	return sign * value, nil
+}
+
+func (p *jsonParser) skipDigits() int {
+	start := p.pos
+	for p.pos < len(p.text) && p.text[p.pos] >= '0' && p.text[p.pos] <= '9' {
+		p.pos += 1
+	}
+	return p.pos - start
+}
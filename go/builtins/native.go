@@ -6,24 +6,79 @@ import (
 
 var native = library.MergeMaps(
 	map[string]any{
-		"contains":   funcContains,
-		"endsWith":   funcEndsWith,
-		"error":      funcError,
-		"fromJSON":   funcFromJSON,
-		"has":        funcHas,
-		"in":         funcIn,
-		"keys":       funcKeys,
-		"length":     funcLength,
-		"now":        funcNow,
-		"startsWith": funcStartsWith,
-		"toJSON":     funcToJSON,
-		"toNumber":   funcToNumber,
-		"toString":   funcToString,
-		"trim":       funcTrim,
-		"trimEnd":    funcTrimEnd,
-		"trimStart":  funcTrimStart,
-		"type":       funcType,
-		"void":       funcVoid,
+		"applyPatch":      funcApplyPatch,
+		"chunk":           funcChunk,
+		"compact":         funcCompact,
+		"contains":        funcContains,
+		"countBy":         funcCountBy,
+		"deepMerge":       funcDeepMerge,
+		"deletePaths":     funcDeletePaths,
+		"diffPatch":       funcDiffPatch,
+		"drop":            funcDrop,
+		"endsWith":        funcEndsWith,
+		"error":           funcError,
+		"filterKeys":      funcFilterKeys,
+		"flatten":         funcFlatten,
+		"fold":            funcFold,
+		"format":          funcFormat,
+		"fromJSON":        funcFromJSON,
+		"fromStream":      funcFromStream,
+		"getPath":         funcGetPath,
+		"getPointer":      funcGetPointer,
+		"groupByKey":      funcGroupByKey,
+		"has":             funcHas,
+		"in":              funcIn,
+		"indexBy":         funcIndexBy,
+		"indexOf":         funcIndexOf,
+		"indicesOf":       funcIndicesOf,
+		"insertAt":        funcInsertAt,
+		"invert":          funcInvert,
+		"joinBy":          funcJoinBy,
+		"keys":            funcKeys,
+		"length":          funcLength,
+		"mapKeys":         funcMapKeys,
+		"matches":         funcMatches,
+		"mergePatch":      funcMergePatch,
+		"now":             funcNow,
+		"omit":            funcOmit,
+		"parseNumber":     funcParseNumber,
+		"partition":       funcPartition,
+		"paths":           funcPaths,
+		"pick":            funcPick,
+		"recurseWithPath": funcRecurseWithPath,
+		"renameKeys":      funcRenameKeys,
+		"replace":         funcReplace,
+		"rethrow":         funcRethrow,
+		"scan":            funcScan,
+		"setPath":         funcSetPath,
+		"setPointer":      funcSetPointer,
+		"sortWith":        funcSortWith,
+		"split":           funcSplit,
+		"startsWith":      funcStartsWith,
+		"sumBy":           funcSumBy,
+		"take":            funcTake,
+		"test":            funcTest,
+		"toBoolean":       funcToBoolean,
+		"toExponential":   funcToExponential,
+		"toFixed":         funcToFixed,
+		"toInteger":       funcToInteger,
+		"toJSON":          funcToJSON,
+		"toNumber":        funcToNumber,
+		"toPrecision":     funcToPrecision,
+		"toStream":        funcToStream,
+		"toString":        funcToString,
+		"transpose":       funcTranspose,
+		"trim":            funcTrim,
+		"trimEnd":         funcTrimEnd,
+		"trimStart":       funcTrimStart,
+		"type":            funcType,
+		"validate":        funcValidate,
+		"void":            funcVoid,
+		"walk":            funcWalk,
+		"window":          funcWindow,
+		"zip":             funcZip,
 	},
 	funcsMath,
+	funcsSets,
+	funcsStats,
 )
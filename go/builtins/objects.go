@@ -0,0 +1,45 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Unwrap the specified value, which must be an object
+func unwrapObject(v any) (map[string]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_OBJECT {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) has no keys", string(t), value))
+	}
+	return value.(map[string]any), nil
+}
+
+// Unwrap the specified object key, which must be a string
+func unwrapKey(v any) (string, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return "", err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return "", err
+	}
+	if t != jpl.JPLT_STRING {
+		return "", library.ThrowAny(library.NewTypeError("cannot use %s (%*<100v) as object key", string(t), value))
+	}
+	return value.(string), nil
+}
+
+// Replace all fields of the specified object with the specified entries
+func replaceEntries(value any, entries []*library.ObjectEntry[any]) (any, jpl.JPLError) {
+	return library.AlterValue(value, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+		return library.ObjectFromEntries(entries), nil
+	}))
+}
@@ -0,0 +1,113 @@
+package builtins
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Unwrap the specified path, which must be an array of keys
+func unwrapPath(v any) ([]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_ARRAY {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a path", string(t), value))
+	}
+	return library.UnwrapValues(value, "path")
+}
+
+// Unwrap the specified list of paths
+func unwrapPaths(v any) ([][]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_ARRAY {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a list of paths", string(t), value))
+	}
+	return library.MuxOne([][]any{value.([]any)}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+		return unwrapPath(args[0])
+	}))
+}
+
+// Unwrap the specified list of paths, which may contain single keys as a shorthand for paths of length one
+func unwrapKeyPaths(v any) ([][]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_ARRAY {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a list of paths", string(t), value))
+	}
+	return library.MuxOne([][]any{value.([]any)}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+		key, err := library.UnwrapValue(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := key.([]any); ok {
+			return unwrapPath(key)
+		}
+		return []any{key}, nil
+	}))
+}
+
+// Visit all values below the specified value in pre-order.
+// Array items are visited by index, object fields are visited in sorted key order.
+func walkPaths(signal jpl.JPLRuntimeSignal, value any, path []any, cb func(path []any, value any) jpl.JPLError) jpl.JPLError {
+	if err := signal.CheckHealth(); err != nil {
+		return err
+	}
+
+	v, err := library.UnwrapValue(value)
+	if err != nil {
+		return err
+	}
+
+	switch v := v.(type) {
+	case []any:
+		for i, item := range v {
+			p := append(slices.Clip(path), float64(i))
+			if err := cb(p, item); err != nil {
+				return err
+			}
+			if err := walkPaths(signal, item, p, cb); err != nil {
+				return err
+			}
+		}
+
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			p := append(slices.Clip(path), key)
+			if err := cb(p, v[key]); err != nil {
+				return err
+			}
+			if err := walkPaths(signal, v[key], p, cb); err != nil {
+				return err
+			}
+		}
+
+	default:
+	}
+
+	return nil
+}
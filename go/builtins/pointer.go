@@ -0,0 +1,150 @@
+package builtins
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+var pointerInvalidEscape = regexp.MustCompile(`~([^01]|$)`)
+
+var pointerArrayIndex = regexp.MustCompile(`^(0|[1-9][0-9]*)$`)
+
+// Unwrap the specified JSON pointer (RFC 6901) and resolve it to a path of keys within the specified value
+func resolvePointer(value any, v any) ([]any, jpl.JPLError) {
+	pointer, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_STRING {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a JSON pointer", string(t), pointer))
+	}
+	tokens, ok := parsePointer(pointer.(string))
+	if !ok {
+		return nil, library.ThrowAny(library.NewRuntimeError("invalid JSON pointer (%*<100v)", pointer))
+	}
+	path, message, err := pointerPath(value, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if message != "" {
+		return nil, library.ThrowAny(library.NewRuntimeError("cannot resolve JSON pointer (%*<100v): %s", pointer, message))
+	}
+	return path, nil
+}
+
+// Parse the specified JSON pointer (RFC 6901) into its unescaped reference tokens.
+// The result is false if the pointer is malformed.
+func parsePointer(pointer string) ([]string, bool) {
+	if pointer == "" {
+		return []string{}, true
+	}
+	if pointer[0] != '/' || pointerInvalidEscape.MatchString(pointer) {
+		return nil, false
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+	return tokens, true
+}
+
+// Format the specified path of keys as a JSON pointer (RFC 6901)
+func formatPointer(path []any) string {
+	var b strings.Builder
+	for _, key := range path {
+		b.WriteByte('/')
+		switch k := key.(type) {
+		case string:
+			b.WriteString(pointerEscaper.Replace(k))
+		case float64:
+			b.WriteString(strconv.FormatFloat(k, 'f', -1, 64))
+		}
+	}
+	return b.String()
+}
+
+// Resolve the specified reference tokens to a path of keys within the specified value.
+//
+// Tokens that refer to array items must be valid array indices or `-`, which refers to the end of the array.
+// Tokens that refer to values that do not exist are treated as object keys.
+// If the tokens cannot be resolved, a message describing the problem is returned.
+func pointerPath(value any, tokens []string) ([]any, string, jpl.JPLError) {
+	path := make([]any, len(tokens))
+	current := value
+	for i, token := range tokens {
+		v, err := library.UnwrapValue(current)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch v := v.(type) {
+		case []any:
+			index := len(v)
+			if token != "-" {
+				if !pointerArrayIndex.MatchString(token) {
+					return nil, "invalid array index " + strconv.Quote(token), nil
+				}
+				index, _ = strconv.Atoi(token)
+			}
+			path[i] = float64(index)
+			current = nil
+			if index < len(v) {
+				current = v[index]
+			}
+
+		case map[string]any:
+			path[i] = token
+			current = v[token]
+
+		default:
+			path[i] = token
+			current = nil
+		}
+	}
+	return path, "", nil
+}
+
+// Resolve the value at the specified path of keys, reporting whether it exists
+func lookupPath(value any, path []any) (any, bool, jpl.JPLError) {
+	current := value
+	for _, key := range path {
+		v, err := library.UnwrapValue(current)
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch v := v.(type) {
+		case []any:
+			i, ok := key.(float64)
+			if !ok || int(i) >= len(v) {
+				return nil, false, nil
+			}
+			current = v[int(i)]
+
+		case map[string]any:
+			k, ok := key.(string)
+			if !ok {
+				return nil, false, nil
+			}
+			if current, ok = v[k]; !ok {
+				return nil, false, nil
+			}
+
+		default:
+			return nil, false, nil
+		}
+	}
+	return current, true, nil
+}
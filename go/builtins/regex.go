@@ -0,0 +1,42 @@
+package builtins
+
+import (
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Unwrap the specified value, which must be a string that can be matched against a regular expression
+func unwrapSubject(v any) (string, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return "", err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return "", err
+	}
+	if t != jpl.JPLT_STRING {
+		return "", library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be matched against a regular expression", string(t), value))
+	}
+	return value.(string), nil
+}
+
+// Unwrap the specified regular expression argument
+func unwrapRegex(v any) (*library.Regex, jpl.JPLError) {
+	regex, err := library.ResolveRegex(v)
+	if err != nil {
+		return nil, err
+	}
+	if regex == nil {
+		value, err := library.UnwrapValue(v)
+		if err != nil {
+			return nil, err
+		}
+		t, err := library.Type(value)
+		if err != nil {
+			return nil, err
+		}
+		return nil, library.ThrowAny(library.NewTypeError("cannot use %s (%*<100v) as regular expression", string(t), value))
+	}
+	return regex, nil
+}
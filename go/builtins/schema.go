@@ -0,0 +1,725 @@
+package builtins
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Validator for JSON schemas (draft 2020-12).
+// Both the schema and the instance are expected to be stripped JSON values.
+type schemaValidator struct {
+	signal   jpl.JPLRuntimeSignal
+	root     map[string]any
+	ids      map[string]any
+	anchors  map[string]any
+	patterns map[string]*regexp.Regexp
+	active   map[string]bool
+	err      jpl.JPLError
+}
+
+// Outcome of validating an instance against a schema.
+// Evaluated properties and items are tracked for `unevaluatedProperties` and `unevaluatedItems`.
+type schemaResult struct {
+	errors     []any
+	properties map[string]bool
+	items      map[int]bool
+}
+
+func (r *schemaResult) valid() bool {
+	return len(r.errors) == 0
+}
+
+func (r *schemaResult) evaluateProperty(key string) {
+	if r.properties == nil {
+		r.properties = map[string]bool{}
+	}
+	r.properties[key] = true
+}
+
+func (r *schemaResult) evaluateItem(i int) {
+	if r.items == nil {
+		r.items = map[int]bool{}
+	}
+	r.items[i] = true
+}
+
+// Include the outcome of a subschema that has been applied to the same instance.
+// Annotations are only kept if the subschema has been successful.
+func (r *schemaResult) include(other *schemaResult, keepErrors bool) {
+	if keepErrors {
+		r.errors = append(r.errors, other.errors...)
+	}
+	if !other.valid() {
+		return
+	}
+	for key := range other.properties {
+		r.evaluateProperty(key)
+	}
+	for i := range other.items {
+		r.evaluateItem(i)
+	}
+}
+
+// Create a validator for the specified root schema
+func newSchemaValidator(signal jpl.JPLRuntimeSignal, schema any) *schemaValidator {
+	v := &schemaValidator{
+		signal:   signal,
+		ids:      map[string]any{},
+		anchors:  map[string]any{},
+		patterns: map[string]*regexp.Regexp{},
+		active:   map[string]bool{},
+	}
+	if root, ok := schema.(map[string]any); ok {
+		v.root = root
+	}
+	v.collect(schema)
+	return v
+}
+
+// Collect all identifiers and anchors of the specified schema and its subschemas
+func (v *schemaValidator) collect(schema any) {
+	switch s := schema.(type) {
+	case map[string]any:
+		if id, ok := s["$id"].(string); ok {
+			v.ids[strings.TrimSuffix(id, "#")] = s
+		}
+		if anchor, ok := s["$anchor"].(string); ok {
+			v.anchors[anchor] = s
+		}
+		if anchor, ok := s["$dynamicAnchor"].(string); ok {
+			v.anchors[anchor] = s
+		}
+		for key, value := range s {
+			switch key {
+			case "const", "default", "enum", "examples":
+			default:
+				v.collect(value)
+			}
+		}
+
+	case []any:
+		for _, value := range s {
+			v.collect(value)
+		}
+
+	default:
+	}
+}
+
+// Record a validation error
+func (v *schemaValidator) fail(r *schemaResult, ip, sp []any, tmpl string, replacements ...any) {
+	message, err := library.Template(tmpl, replacements...)
+	if err != nil {
+		if v.err == nil {
+			v.err = err
+		}
+		return
+	}
+	r.errors = append(r.errors, map[string]any{
+		"instancePath": formatPointer(ip),
+		"schemaPath":   formatPointer(sp),
+		"message":      message,
+	})
+}
+
+// Compile the specified regular expression, which is cached for subsequent validations
+func (v *schemaValidator) pattern(pattern string) (*regexp.Regexp, jpl.JPLError) {
+	if re, ok := v.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, library.ThrowAny(library.NewRuntimeError("invalid pattern (%*<100v) in JSON schema", pattern))
+	}
+	v.patterns[pattern] = re
+	return re, nil
+}
+
+// Resolve the specified schema reference.
+//
+// References may point to the root schema (`#`), JSON pointers within the root schema (`#/$defs/a`),
+// anchors (`#a`) and identifiers of embedded schemas, optionally followed by a fragment.
+func (v *schemaValidator) resolve(ref string) (any, bool) {
+	base, fragment, _ := strings.Cut(ref, "#")
+	var schema any = v.root
+	if base != "" {
+		var ok bool
+		if schema, ok = v.ids[base]; !ok {
+			return nil, false
+		}
+	}
+	if fragment != "" && fragment[0] != '/' {
+		schema, ok := v.anchors[fragment]
+		return schema, ok
+	}
+	tokens, ok := parsePointer(fragment)
+	if !ok {
+		return nil, false
+	}
+	path, message, err := pointerPath(schema, tokens)
+	if err != nil || message != "" {
+		return nil, false
+	}
+	schema, found, err := lookupPath(schema, path)
+	return schema, err == nil && found
+}
+
+// Validate the specified instance against the specified schema
+func (v *schemaValidator) validate(instance any, schema any, ip, sp []any) (*schemaResult, jpl.JPLError) {
+	if err := v.signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	r := &schemaResult{}
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			v.fail(r, ip, sp, "value is not allowed")
+		}
+
+	case map[string]any:
+		if err := v.validateObject(r, instance, s, ip, sp); err != nil {
+			return nil, err
+		}
+
+	default:
+		t, err := library.Type(schema)
+		if err != nil {
+			return nil, err
+		}
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as a JSON schema", string(t), schema))
+	}
+
+	if v.err != nil {
+		return nil, v.err
+	}
+	return r, nil
+}
+
+func (v *schemaValidator) validateObject(r *schemaResult, instance any, s map[string]any, ip, sp []any) jpl.JPLError {
+	at := func(keys ...any) []any {
+		return append(slices.Clip(sp), keys...)
+	}
+	child := func(key any) []any {
+		return append(slices.Clip(ip), key)
+	}
+
+	for _, keyword := range []string{"$ref", "$dynamicRef"} {
+		ref, ok := s[keyword].(string)
+		if !ok {
+			continue
+		}
+		target, found := v.resolve(ref)
+		if !found {
+			return library.ThrowAny(library.NewRuntimeError("cannot resolve JSON schema reference (%*<100v)", ref))
+		}
+		// Applying the same reference to the same instance again would never terminate
+		key := ref + "\x00" + formatPointer(ip)
+		if v.active[key] {
+			return library.ThrowAny(library.NewRuntimeError("circular JSON schema reference (%*<100v)", ref))
+		}
+		v.active[key] = true
+		sub, err := v.validate(instance, target, ip, at(keyword))
+		delete(v.active, key)
+		if err != nil {
+			return err
+		}
+		r.include(sub, true)
+	}
+
+	t, err := library.Type(instance)
+	if err != nil {
+		return err
+	}
+
+	if types, ok := schemaTypes(s["type"]); ok {
+		if !slices.ContainsFunc(types, func(name string) bool { return schemaTypeMatches(name, t, instance) }) {
+			v.fail(r, ip, at("type"), "expected %s, got %s", strings.Join(types, " or "), string(t))
+		}
+	}
+	if enum, ok := s["enum"].([]any); ok {
+		if !slices.ContainsFunc(enum, func(value any) bool { return schemaEquals(instance, value) }) {
+			v.fail(r, ip, at("enum"), "value must be one of %*<100v", enum)
+		}
+	}
+	if value, ok := s["const"]; ok && !schemaEquals(instance, value) {
+		v.fail(r, ip, at("const"), "value must be %*<100v", value)
+	}
+
+	switch value := instance.(type) {
+	case float64:
+		if limit, ok := s["multipleOf"].(float64); ok && limit > 0 {
+			// The quotient is compared with a relative tolerance, as decimal fractions like 0.1 cannot be represented exactly
+			if q := value / limit; math.IsInf(q, 0) || math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+				v.fail(r, ip, at("multipleOf"), "value must be a multiple of %v", limit)
+			}
+		}
+		if limit, ok := s["maximum"].(float64); ok && value > limit {
+			v.fail(r, ip, at("maximum"), "value must be at most %v", limit)
+		}
+		if limit, ok := s["exclusiveMaximum"].(float64); ok && value >= limit {
+			v.fail(r, ip, at("exclusiveMaximum"), "value must be less than %v", limit)
+		}
+		if limit, ok := s["minimum"].(float64); ok && value < limit {
+			v.fail(r, ip, at("minimum"), "value must be at least %v", limit)
+		}
+		if limit, ok := s["exclusiveMinimum"].(float64); ok && value <= limit {
+			v.fail(r, ip, at("exclusiveMinimum"), "value must be greater than %v", limit)
+		}
+
+	case string:
+		l := float64(utf8.RuneCountInString(value))
+		if limit, ok := s["maxLength"].(float64); ok && l > limit {
+			v.fail(r, ip, at("maxLength"), "string must be at most %v characters long", limit)
+		}
+		if limit, ok := s["minLength"].(float64); ok && l < limit {
+			v.fail(r, ip, at("minLength"), "string must be at least %v characters long", limit)
+		}
+		if pattern, ok := s["pattern"].(string); ok {
+			re, err := v.pattern(pattern)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(value) {
+				v.fail(r, ip, at("pattern"), "string must match pattern %v", pattern)
+			}
+		}
+		if format, ok := s["format"].(string); ok && !schemaFormatMatches(format, value) {
+			v.fail(r, ip, at("format"), "string must be a valid %s", format)
+		}
+
+	case []any:
+		if err := v.validateArray(r, value, s, ip, at, child); err != nil {
+			return err
+		}
+
+	case map[string]any:
+		if err := v.validateProperties(r, value, s, ip, at, child); err != nil {
+			return err
+		}
+
+	default:
+	}
+
+	if schemas, ok := s["allOf"].([]any); ok {
+		for i, schema := range schemas {
+			sub, err := v.validate(instance, schema, ip, at("allOf", float64(i)))
+			if err != nil {
+				return err
+			}
+			r.include(sub, true)
+		}
+	}
+	if schemas, ok := s["anyOf"].([]any); ok {
+		matches := 0
+		for i, schema := range schemas {
+			sub, err := v.validate(instance, schema, ip, at("anyOf", float64(i)))
+			if err != nil {
+				return err
+			}
+			if sub.valid() {
+				matches += 1
+			}
+			r.include(sub, false)
+		}
+		if matches == 0 {
+			v.fail(r, ip, at("anyOf"), "value must match at least one schema")
+		}
+	}
+	if schemas, ok := s["oneOf"].([]any); ok {
+		matches := 0
+		for i, schema := range schemas {
+			sub, err := v.validate(instance, schema, ip, at("oneOf", float64(i)))
+			if err != nil {
+				return err
+			}
+			if sub.valid() {
+				matches += 1
+			}
+			r.include(sub, false)
+		}
+		if matches != 1 {
+			v.fail(r, ip, at("oneOf"), "value must match exactly one schema, but matches %v", float64(matches))
+		}
+	}
+	if schema, ok := s["not"]; ok {
+		sub, err := v.validate(instance, schema, ip, at("not"))
+		if err != nil {
+			return err
+		}
+		if sub.valid() {
+			v.fail(r, ip, at("not"), "value must not match schema")
+		}
+	}
+	if schema, ok := s["if"]; ok {
+		sub, err := v.validate(instance, schema, ip, at("if"))
+		if err != nil {
+			return err
+		}
+		r.include(sub, false)
+		branch := "else"
+		if sub.valid() {
+			branch = "then"
+		}
+		if schema, ok := s[branch]; ok {
+			sub, err := v.validate(instance, schema, ip, at(branch))
+			if err != nil {
+				return err
+			}
+			r.include(sub, true)
+		}
+	}
+
+	// Unevaluated items and properties depend on the annotations of all other keywords
+	switch value := instance.(type) {
+	case []any:
+		if schema, ok := s["unevaluatedItems"]; ok {
+			for i, item := range value {
+				if r.items[i] {
+					continue
+				}
+				sub, err := v.validate(item, schema, child(float64(i)), at("unevaluatedItems"))
+				if err != nil {
+					return err
+				}
+				r.errors = append(r.errors, sub.errors...)
+				r.evaluateItem(i)
+			}
+		}
+
+	case map[string]any:
+		if schema, ok := s["unevaluatedProperties"]; ok {
+			for _, key := range sortedKeys(value) {
+				if r.properties[key] {
+					continue
+				}
+				sub, err := v.validate(value[key], schema, child(key), at("unevaluatedProperties"))
+				if err != nil {
+					return err
+				}
+				r.errors = append(r.errors, sub.errors...)
+				r.evaluateProperty(key)
+			}
+		}
+
+	default:
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) validateArray(r *schemaResult, value []any, s map[string]any, ip []any, at func(keys ...any) []any, child func(key any) []any) jpl.JPLError {
+	l := float64(len(value))
+	if limit, ok := s["maxItems"].(float64); ok && l > limit {
+		v.fail(r, ip, at("maxItems"), "array must have at most %v items", limit)
+	}
+	if limit, ok := s["minItems"].(float64); ok && l < limit {
+		v.fail(r, ip, at("minItems"), "array must have at least %v items", limit)
+	}
+	if unique, ok := s["uniqueItems"].(bool); ok && unique {
+	outer:
+		for i := range value {
+			for j := i + 1; j < len(value); j += 1 {
+				if schemaEquals(value[i], value[j]) {
+					v.fail(r, ip, at("uniqueItems"), "array items must be unique, but items %v and %v are equal", float64(i), float64(j))
+					break outer
+				}
+			}
+		}
+	}
+
+	prefix := 0
+	if schemas, ok := s["prefixItems"].([]any); ok {
+		for i, schema := range schemas {
+			if i >= len(value) {
+				break
+			}
+			sub, err := v.validate(value[i], schema, child(float64(i)), at("prefixItems", float64(i)))
+			if err != nil {
+				return err
+			}
+			r.errors = append(r.errors, sub.errors...)
+			r.evaluateItem(i)
+		}
+		prefix = len(schemas)
+	}
+	if schema, ok := s["items"]; ok {
+		for i := prefix; i < len(value); i += 1 {
+			sub, err := v.validate(value[i], schema, child(float64(i)), at("items"))
+			if err != nil {
+				return err
+			}
+			r.errors = append(r.errors, sub.errors...)
+			r.evaluateItem(i)
+		}
+	}
+	if schema, ok := s["contains"]; ok {
+		matches := 0
+		for i, item := range value {
+			sub, err := v.validate(item, schema, child(float64(i)), at("contains"))
+			if err != nil {
+				return err
+			}
+			if sub.valid() {
+				matches += 1
+				r.evaluateItem(i)
+			}
+		}
+		minContains := 1.0
+		if limit, ok := s["minContains"].(float64); ok {
+			minContains = limit
+		}
+		if float64(matches) < minContains {
+			v.fail(r, ip, at("contains"), "array must contain at least %v matching items", minContains)
+		}
+		if limit, ok := s["maxContains"].(float64); ok && float64(matches) > limit {
+			v.fail(r, ip, at("maxContains"), "array must contain at most %v matching items", limit)
+		}
+	}
+	return nil
+}
+
+func (v *schemaValidator) validateProperties(r *schemaResult, value map[string]any, s map[string]any, ip []any, at func(keys ...any) []any, child func(key any) []any) jpl.JPLError {
+	keys := sortedKeys(value)
+
+	l := float64(len(value))
+	if limit, ok := s["maxProperties"].(float64); ok && l > limit {
+		v.fail(r, ip, at("maxProperties"), "object must have at most %v properties", limit)
+	}
+	if limit, ok := s["minProperties"].(float64); ok && l < limit {
+		v.fail(r, ip, at("minProperties"), "object must have at least %v properties", limit)
+	}
+	if required, ok := s["required"].([]any); ok {
+		for _, key := range required {
+			if k, ok := key.(string); ok {
+				if _, ok := value[k]; !ok {
+					v.fail(r, ip, at("required"), "missing required property %v", k)
+				}
+			}
+		}
+	}
+	if dependencies, ok := s["dependentRequired"].(map[string]any); ok {
+		for _, key := range sortedKeys(dependencies) {
+			required, ok := dependencies[key].([]any)
+			if _, present := value[key]; !ok || !present {
+				continue
+			}
+			for _, dependency := range required {
+				if d, ok := dependency.(string); ok {
+					if _, ok := value[d]; !ok {
+						v.fail(r, ip, at("dependentRequired", key), "property %v requires property %v", key, d)
+					}
+				}
+			}
+		}
+	}
+
+	properties, _ := s["properties"].(map[string]any)
+	patternProperties, _ := s["patternProperties"].(map[string]any)
+	patterns := sortedKeys(patternProperties)
+	additionalProperties, hasAdditionalProperties := s["additionalProperties"]
+	for _, key := range keys {
+		matched := false
+		if schema, ok := properties[key]; ok {
+			matched = true
+			sub, err := v.validate(value[key], schema, child(key), at("properties", key))
+			if err != nil {
+				return err
+			}
+			r.errors = append(r.errors, sub.errors...)
+		}
+		for _, pattern := range patterns {
+			re, err := v.pattern(pattern)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(key) {
+				continue
+			}
+			matched = true
+			sub, err := v.validate(value[key], patternProperties[pattern], child(key), at("patternProperties", pattern))
+			if err != nil {
+				return err
+			}
+			r.errors = append(r.errors, sub.errors...)
+		}
+		if !matched && hasAdditionalProperties {
+			matched = true
+			sub, err := v.validate(value[key], additionalProperties, child(key), at("additionalProperties"))
+			if err != nil {
+				return err
+			}
+			r.errors = append(r.errors, sub.errors...)
+		}
+		if matched {
+			r.evaluateProperty(key)
+		}
+	}
+
+	if schema, ok := s["propertyNames"]; ok {
+		for _, key := range keys {
+			sub, err := v.validate(key, schema, child(key), at("propertyNames"))
+			if err != nil {
+				return err
+			}
+			r.errors = append(r.errors, sub.errors...)
+		}
+	}
+	if dependencies, ok := s["dependentSchemas"].(map[string]any); ok {
+		for _, key := range sortedKeys(dependencies) {
+			if _, ok := value[key]; !ok {
+				continue
+			}
+			sub, err := v.validate(value, dependencies[key], ip, at("dependentSchemas", key))
+			if err != nil {
+				return err
+			}
+			r.include(sub, true)
+		}
+	}
+	return nil
+}
+
+func sortedKeys[Value any](m map[string]Value) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func schemaEquals(a, b any) bool {
+	equal, err := library.Equals(a, b)
+	return err == nil && equal
+}
+
+// Unwrap the value of the `type` keyword, which is either a single type name or a list of type names
+func schemaTypes(value any) ([]string, bool) {
+	switch value := value.(type) {
+	case string:
+		return []string{value}, true
+
+	case []any:
+		types := make([]string, 0, len(value))
+		for _, entry := range value {
+			if name, ok := entry.(string); ok {
+				types = append(types, name)
+			}
+		}
+		return types, true
+
+	default:
+	}
+	return nil, false
+}
+
+func schemaTypeMatches(name string, t jpl.JPLDataType, value any) bool {
+	if name == "integer" {
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n) && !math.IsInf(n, 0)
+	}
+	return name == string(t)
+}
+
+var (
+	formatDate     = regexp.MustCompile(`^(\d{4})-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$`)
+	formatTime     = regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d:([0-5]\d|60)(\.\d+)?([Zz]|[+-]([01]\d|2[0-3]):[0-5]\d)$`)
+	formatEmail    = regexp.MustCompile(`^[^\s@]+@[^\s@]+$`)
+	formatHostname = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+	formatIPv4     = regexp.MustCompile(`^((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)$`)
+	formatIPv6     = regexp.MustCompile(`^[0-9A-Fa-f]{1,4}$`)
+	formatURI      = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.\-]*:[^\s]*$`)
+	formatURIRef   = regexp.MustCompile(`^[^\s]*$`)
+	formatUUID     = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+)
+
+// Check whether the specified string matches the specified format.
+// Unknown formats are always considered to match.
+func schemaFormatMatches(format string, value string) bool {
+	switch format {
+	case "date-time":
+		date, t, ok := strings.Cut(value, "T")
+		if !ok {
+			date, t, ok = strings.Cut(value, "t")
+		}
+		return ok && isDate(date) && formatTime.MatchString(t)
+	case "date":
+		return isDate(value)
+	case "time":
+		return formatTime.MatchString(value)
+	case "email":
+		return formatEmail.MatchString(value)
+	case "hostname":
+		return len(value) <= 253 && formatHostname.MatchString(value)
+	case "ipv4":
+		return formatIPv4.MatchString(value)
+	case "ipv6":
+		return isIPv6(value)
+	case "uri":
+		return formatURI.MatchString(value)
+	case "uri-reference":
+		return formatURIRef.MatchString(value)
+	case "uuid":
+		return formatUUID.MatchString(value)
+	case "regex":
+		_, err := regexp.Compile(value)
+		return err == nil
+	case "json-pointer":
+		_, ok := parsePointer(value)
+		return ok
+	default:
+		return true
+	}
+}
+
+func isDate(value string) bool {
+	parts := formatDate.FindStringSubmatch(value)
+	if parts == nil {
+		return false
+	}
+	year, _ := strconv.Atoi(parts[1])
+	month, _ := strconv.Atoi(parts[2])
+	day, _ := strconv.Atoi(parts[3])
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Day() == day
+}
+
+func isIPv6(value string) bool {
+	groups := 8
+	// An embedded IPv4 address takes the place of the last two groups
+	if i := strings.LastIndexByte(value, ':'); i >= 0 && strings.Contains(value[i+1:], ".") {
+		if !formatIPv4.MatchString(value[i+1:]) {
+			return false
+		}
+		value = value[:i+1] + "0"
+		groups = 7
+	}
+	parts := strings.Split(value, "::")
+	if len(parts) > 2 {
+		return false
+	}
+	count := 0
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		for _, group := range strings.Split(part, ":") {
+			if !formatIPv6.MatchString(group) {
+				return false
+			}
+			count += 1
+		}
+	}
+	if len(parts) == 2 {
+		return count < groups
+	}
+	return count == groups
+}
@@ -0,0 +1,190 @@
+package builtins
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Create a key for the specified value, which is the same for all values that are considered equal
+func hashKey(value any) (string, jpl.JPLError) {
+	v, err := library.StripJSON(value)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	writeHashKey(&b, v)
+	return b.String(), nil
+}
+
+func writeHashKey(b *strings.Builder, value any) {
+	switch v := value.(type) {
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+
+	case float64:
+		// Normalize negative zero, which is equal to zero
+		b.WriteString(strconv.FormatFloat(v+0, 'g', -1, 64))
+
+	case string:
+		b.WriteString(strconv.Quote(v))
+
+	case []any:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeHashKey(b, item)
+		}
+		b.WriteByte(']')
+
+	case map[string]any:
+		b.WriteByte('{')
+		for i, key := range sortedKeys(v) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(key))
+			b.WriteByte(':')
+			writeHashKey(b, v[key])
+		}
+		b.WriteByte('}')
+
+	default:
+		b.WriteString("null")
+	}
+}
+
+// Create keys for all specified items.
+// If a function is specified, items are keyed by all of its outputs, otherwise by themselves.
+func hashKeys(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, items []any, f jpl.JPLFunc) ([]string, jpl.JPLError) {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		if err := signal.CheckHealth(); err != nil {
+			return nil, err
+		}
+		key := item
+		if f != nil {
+			results, err := callFunction(runtime, signal, f, item)
+			if err != nil {
+				return nil, err
+			}
+			key = results
+		}
+		var err jpl.JPLError
+		if keys[i], err = hashKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Set operation, which selects the items of both arrays to be included in the result based on their keys
+type setOperation = func(a, b []string) (selectA, selectB []bool)
+
+// Create a set operation builtin.
+// If `by` is set, the builtin accepts a function as its second argument, which is used for keying the items.
+func funcSet(by bool, op setOperation) jpl.JPLFunc {
+	return func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+		var arg0, arg1 any
+		if len(args) > 0 {
+			arg0 = args[0]
+		}
+		if len(args) > 1 {
+			arg1 = args[1]
+		}
+		a, err := unwrapArray(input)
+		if err != nil {
+			return nil, err
+		}
+		b, err := unwrapArray(arg0)
+		if err != nil {
+			return nil, err
+		}
+		var f jpl.JPLFunc
+		if by {
+			if f, err = unwrapFunction(arg1); err != nil {
+				return nil, err
+			}
+		}
+
+		ka, err := hashKeys(runtime, signal, a, f)
+		if err != nil {
+			return nil, err
+		}
+		kb, err := hashKeys(runtime, signal, b, f)
+		if err != nil {
+			return nil, err
+		}
+
+		selectA, selectB := op(ka, kb)
+		result := []any{}
+		for i, item := range a {
+			if selectA[i] {
+				result = append(result, item)
+			}
+		}
+		for i, item := range b {
+			if selectB[i] {
+				result = append(result, item)
+			}
+		}
+		return next.Pipe(result)
+	}
+}
+
+func keySet(keys []string) map[string]bool {
+	result := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		result[key] = true
+	}
+	return result
+}
+
+// Select the first occurrence of each key that matches the specified filter
+func selectUnique(keys []string, seen map[string]bool, filter func(key string) bool) []bool {
+	result := make([]bool, len(keys))
+	for i, key := range keys {
+		if !seen[key] && filter(key) {
+			seen[key] = true
+			result[i] = true
+		}
+	}
+	return result
+}
+
+func unionSets(a, b []string) ([]bool, []bool) {
+	seen := map[string]bool{}
+	all := func(key string) bool { return true }
+	return selectUnique(a, seen, all), selectUnique(b, seen, all)
+}
+
+func intersectionSets(a, b []string) ([]bool, []bool) {
+	inB := keySet(b)
+	return selectUnique(a, map[string]bool{}, func(key string) bool { return inB[key] }), make([]bool, len(b))
+}
+
+func differenceSets(a, b []string) ([]bool, []bool) {
+	inB := keySet(b)
+	return selectUnique(a, map[string]bool{}, func(key string) bool { return !inB[key] }), make([]bool, len(b))
+}
+
+func symmetricDifferenceSets(a, b []string) ([]bool, []bool) {
+	inA, inB := keySet(a), keySet(b)
+	seen := map[string]bool{}
+	return selectUnique(a, seen, func(key string) bool { return !inB[key] }), selectUnique(b, seen, func(key string) bool { return !inA[key] })
+}
+
+var funcsSets = map[string]any{
+	"union":                 funcSet(false, unionSets),
+	"unionBy":               funcSet(true, unionSets),
+	"intersection":          funcSet(false, intersectionSets),
+	"intersectionBy":        funcSet(true, intersectionSets),
+	"difference":            funcSet(false, differenceSets),
+	"differenceBy":          funcSet(true, differenceSets),
+	"symmetricDifference":   funcSet(false, symmetricDifferenceSets),
+	"symmetricDifferenceBy": funcSet(true, symmetricDifferenceSets),
+}
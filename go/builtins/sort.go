@@ -0,0 +1,175 @@
+package builtins
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Options for comparing sort keys
+type sortOptions struct {
+	// Sort direction per key
+	descending []bool
+	// Sort direction of all keys without an individual direction
+	descendingRest bool
+	// Placement of null keys, which is either "first", "last" or empty for their natural order
+	nulls string
+	// Whether strings are compared case-insensitively
+	caseInsensitive bool
+}
+
+// Unwrap the specified sort options, which must be an object or null
+func unwrapSortOptions(v any) (*sortOptions, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	options := &sortOptions{}
+	if t == jpl.JPLT_NULL {
+		return options, nil
+	}
+	if t != jpl.JPLT_OBJECT {
+		return nil, library.ThrowAny(library.NewTypeError("%s (%*<100v) cannot be used as sort options", string(t), value))
+	}
+	fields := value.(map[string]any)
+
+	order, err := library.UnwrapValue(fields["order"])
+	if err != nil {
+		return nil, err
+	}
+	if orders, ok := order.([]any); ok {
+		options.descending = make([]bool, len(orders))
+		for i, o := range orders {
+			if options.descending[i], err = unwrapSortOrder(o); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		// A single direction applies to all keys
+		if options.descendingRest, err = unwrapSortOrder(order); err != nil {
+			return nil, err
+		}
+	}
+
+	nulls, err := library.UnwrapValue(fields["nulls"])
+	if err != nil {
+		return nil, err
+	}
+	switch nulls {
+	case nil:
+	case "first", "last":
+		options.nulls = nulls.(string)
+	default:
+		return nil, library.ThrowAny(library.NewRuntimeError("invalid null placement (%*<100v), expected \"first\" or \"last\"", nulls))
+	}
+
+	caseInsensitive, err := library.UnwrapValue(fields["caseInsensitive"])
+	if err != nil {
+		return nil, err
+	}
+	options.caseInsensitive = caseInsensitive == true
+	return options, nil
+}
+
+// Unwrap the specified sort direction and return whether it is descending
+func unwrapSortOrder(v any) (bool, jpl.JPLError) {
+	o, err := library.UnwrapValue(v)
+	if err != nil {
+		return false, err
+	}
+	switch o {
+	case nil, "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	default:
+		return false, library.ThrowAny(library.NewRuntimeError("invalid sort order (%*<100v), expected \"asc\" or \"desc\"", o))
+	}
+}
+
+// Return whether the sort key at index i is sorted in descending order
+func (o *sortOptions) isDescending(i int) bool {
+	if i < len(o.descending) {
+		return o.descending[i]
+	}
+	return o.descendingRest
+}
+
+// Compare the specified arrays of sort keys based on their lexical order
+func (o *sortOptions) compareKeys(a, b []any) (int, jpl.JPLError) {
+	for i := 0; i < min(len(a), len(b)); i += 1 {
+		c, err := o.compareKey(i, a[i], b[i])
+		if err != nil {
+			return 0, err
+		}
+		if c != 0 {
+			return c, nil
+		}
+	}
+	// Shorter arrays of keys are sorted first, unless the first missing key is sorted in descending order
+	c := len(a) - len(b)
+	if o.isDescending(min(len(a), len(b))) {
+		return -c, nil
+	}
+	return c, nil
+}
+
+// Compare the specified sort keys at index i
+func (o *sortOptions) compareKey(i int, a, b any) (int, jpl.JPLError) {
+	ua, err := library.UnwrapValue(a)
+	if err != nil {
+		return 0, err
+	}
+	ub, err := library.UnwrapValue(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if o.nulls != "" && (ua == nil) != (ub == nil) {
+		// Null placement does not depend on the sort direction
+		if (ua == nil) == (o.nulls == "first") {
+			return -1, nil
+		}
+		return 1, nil
+	}
+
+	var c int
+	sa, okA := ua.(string)
+	sb, okB := ub.(string)
+	if o.caseInsensitive && okA && okB {
+		c, err = library.CompareStrings(strings.ToLower(sa), strings.ToLower(sb))
+	} else {
+		c, err = library.Compare(ua, ub)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if o.isDescending(i) {
+		return -c, nil
+	}
+	return c, nil
+}
+
+// Sort the specified items stably using the specified comparison function.
+// If the comparison fails, sorting is aborted and the first error is returned.
+func sortStable(items []any, cmp func(a, b any) (int, jpl.JPLError)) jpl.JPLError {
+	var sortErr jpl.JPLError
+	slices.SortStableFunc(items, func(a, b any) int {
+		if sortErr != nil {
+			return 0
+		}
+		c, err := cmp(a, b)
+		if err != nil {
+			sortErr = err
+			return 0
+		}
+		return c
+	})
+	return sortErr
+}
@@ -0,0 +1,186 @@
+package builtins
+
+import (
+	"math"
+	"slices"
+
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Unwrap the specified array of numbers
+func unwrapNumbers(v any) ([]float64, jpl.JPLError) {
+	value, err := unwrapArray(v)
+	if err != nil {
+		return nil, err
+	}
+	numbers := make([]float64, len(value))
+	for i, item := range value {
+		if numbers[i], err = unwrapNumber(item); err != nil {
+			return nil, err
+		}
+	}
+	return numbers, nil
+}
+
+type aggregateFunc = func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError)
+
+func funcAggregate(aggregate aggregateFunc) jpl.JPLFunc {
+	return func(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+		values, err := unwrapNumbers(input)
+		if err != nil {
+			return nil, err
+		}
+		result, err := aggregate(runtime, values, args...)
+		if err != nil {
+			return nil, err
+		}
+		return next.Pipe(result)
+	}
+}
+
+func sum(values []float64) float64 {
+	var result float64
+	for _, value := range values {
+		result += value
+	}
+	return result
+}
+
+// Calculate the population variance of the specified values
+func variance(values []float64) float64 {
+	mean := sum(values) / float64(len(values))
+	var result float64
+	for _, value := range values {
+		result += (value - mean) * (value - mean)
+	}
+	return result / float64(len(values))
+}
+
+// Calculate the specified percentile of the specified values using linear interpolation between the closest ranks
+func percentile(values []float64, p float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+var funcsStats = map[string]any{
+	"sum": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		return sum(values), nil
+	}),
+	"mean": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return sum(values) / float64(len(values)), nil
+	}),
+	"median": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return percentile(values, 50), nil
+	}),
+	"percentile": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		var arg0 any
+		if len(args) > 0 {
+			arg0 = args[0]
+		}
+		p, err := unwrapNumber(arg0)
+		if err != nil {
+			return nil, err
+		}
+		if p < 0 || p > 100 {
+			return nil, library.ThrowAny(library.NewRuntimeError("percentile (%*<100v) must be between 0 and 100", p))
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return percentile(values, p), nil
+	}),
+	"variance": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return variance(values), nil
+	}),
+	"stddev": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return math.Sqrt(variance(values)), nil
+	}),
+	"histogram": funcAggregate(func(runtime jpl.JPLRuntime, values []float64, args ...any) (any, jpl.JPLError) {
+		var arg0 any
+		if len(args) > 0 {
+			arg0 = args[0]
+		}
+		bounds, err := histogramBounds(values, arg0)
+		if err != nil {
+			return nil, err
+		}
+
+		counts := make([]int, max(len(bounds)-1, 0))
+		for _, value := range values {
+			// Values outside of the bounds are ignored, while the last bucket also includes its upper bound
+			i, found := slices.BinarySearch(bounds, value)
+			if !found {
+				i -= 1
+			}
+			if i == len(counts) && value == bounds[i] {
+				i -= 1
+			}
+			if i >= 0 && i < len(counts) {
+				counts[i] += 1
+			}
+		}
+
+		result := make([]any, len(counts))
+		for i, count := range counts {
+			result[i] = map[string]any{"from": bounds[i], "to": bounds[i+1], "count": float64(count)}
+		}
+		return result, nil
+	}),
+}
+
+// Determine the bounds of all histogram buckets.
+// Buckets are either specified by a count of equally sized buckets between the smallest and largest value,
+// or by an array of ascending bounds.
+func histogramBounds(values []float64, v any) ([]float64, jpl.JPLError) {
+	value, err := library.UnwrapValue(v)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := value.([]any); ok {
+		bounds, err := unwrapNumbers(value)
+		if err != nil {
+			return nil, err
+		}
+		valid := len(bounds) >= 2
+		for i := 1; valid && i < len(bounds); i++ {
+			valid = bounds[i-1] < bounds[i]
+		}
+		if !valid {
+			return nil, library.ThrowAny(library.NewRuntimeError("histogram bounds (%*<100v) must contain at least two strictly ascending numbers", value))
+		}
+		return bounds, nil
+	}
+
+	n, err := unwrapCount(value, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return []float64{}, nil
+	}
+	lo, hi := slices.Min(values), slices.Max(values)
+	bounds := make([]float64, n+1)
+	for i := range bounds {
+		bounds[i] = lo + (hi-lo)*float64(i)/float64(n)
+	}
+	// The outer bounds are set exactly, as rounding could otherwise exclude the minimum or maximum
+	bounds[0], bounds[n] = lo, hi
+	return bounds, nil
+}
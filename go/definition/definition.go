@@ -1,8 +1,8 @@
 package definition
 
 const DEFINITION_VERSION_MAJOR = 1
-const DEFINITION_VERSION_MINOR = 0
-const DEFINITION_VERSION = "1.0"
+const DEFINITION_VERSION_MINOR = 1
+const DEFINITION_VERSION = "1.1"
 
 type JPLDefinition struct {
 	Version      string           `json:"version"`
@@ -19,15 +19,21 @@ type JPLInstruction struct {
 type JPLInstructionParams struct {
 	After          string             `json:"after,omitempty"`
 	ArgNames       []string           `json:"argNames,omitempty"`
+	Args           []JPLArgument      `json:"args,omitempty"`
 	Assignment     *JPLAssignment     `json:"assignment,omitempty"`
+	Cases          []JPLMatchCase     `json:"cases,omitempty"`
 	Catch          Pipe               `json:"catch,omitempty"`
+	Catches        []JPLCatch         `json:"catches,omitempty"`
 	Comparisons    []JPLComparison    `json:"comparisons,omitempty"`
 	Else           Pipe               `json:"else,omitempty"`
 	Fields         []JPLField         `json:"fields,omitempty"`
+	Flags          string             `json:"flags,omitempty"`
 	Ifs            []JPLIfThen        `json:"ifs,omitempty"`
 	Interpolations []JPLInterpolation `json:"interpolations,omitempty"`
 	Name           string             `json:"name,omitempty"`
 	Operations     []JPLOperation     `json:"operations,omitempty"`
+	Paths          [][]JPLSelector    `json:"paths,omitempty"`
+	Pattern        *JPLPattern        `json:"pattern,omitempty"`
 	Pipe           Pipe               `json:"pipe,omitempty"`
 	Pipes          []Pipe             `json:"params,omitempty"`
 	Selectors      []JPLSelector      `json:"selectors,omitempty"`
@@ -87,6 +93,48 @@ type JPLField struct {
 	Key      Pipe `json:"key"`
 	Value    Pipe `json:"value"`
 	Optional bool `json:"optional,omitempty"`
+	Spread   bool `json:"spread,omitempty"`
+}
+
+type JPLPattern struct {
+	OP     JPLOPD           `json:"op"`
+	Params JPLPatternParams `json:"params"`
+}
+
+type JPLPatternParams struct {
+	Entries []JPLPatternEntry `json:"entries,omitempty"`
+	Name    string            `json:"name,omitempty"`
+	Pattern *JPLPattern       `json:"pattern,omitempty"`
+	Pipe    Pipe              `json:"pipe,omitempty"`
+	Rest    string            `json:"rest,omitempty"`
+	Type    string            `json:"type,omitempty"`
+}
+
+type JPLPatternEntry struct {
+	Key      Pipe       `json:"key,omitempty"`
+	Pattern  JPLPattern `json:"pattern"`
+	Default  Pipe       `json:"default,omitempty"`
+	Optional bool       `json:"optional,omitempty"`
+}
+
+type JPLMatchCase struct {
+	Pattern JPLPattern `json:"pattern"`
+	If      Pipe       `json:"if,omitempty"`
+	Then    Pipe       `json:"then"`
+}
+
+type JPLCatch struct {
+	Kinds []string `json:"kinds,omitempty"`
+	When  Pipe     `json:"when,omitempty"`
+	Raw   bool     `json:"raw,omitempty"`
+	Pipe  Pipe     `json:"pipe"`
+}
+
+type JPLArgument struct {
+	Name     string   `json:"name"`
+	Types    []string `json:"types,omitempty"`
+	Optional bool     `json:"optional,omitempty"`
+	Default  Pipe     `json:"default,omitempty"`
 }
 
 type JPLInterpolation struct {
@@ -23,6 +23,11 @@ const OP_ARRAY_CONSTRUCTOR = JPLOP("[]")
 // { pipe: [op], selectors: [opa], assignment: [opu] }
 const OP_ASSIGNMENT = JPLOP("$=")
 
+// { name: string }
+//
+// { name: string }
+const OP_BREAK = JPLOP("brk")
+
 // { pipe: function, operations: [opm] }
 //
 // { pipe: [op], operations: [opm] }
@@ -53,9 +58,19 @@ const OP_CONSTANT_NULL = JPLOP("nul")
 // {}
 const OP_CONSTANT_TRUE = JPLOP("tru")
 
-// { argNames: [string], pipe: function }
+// { paths: [[opa]] }
+//
+// { paths: [[opa]] }
+const OP_DELETE = JPLOP("del")
+
+// { pattern: opd, pipe: function }
+//
+// { pattern: opd, pipe: [op] }
+const OP_DESTRUCTURING_DEFINITION = JPLOP("ds=")
+
+// { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: function }], pipe: function }
 //
-// { argNames: [string], pipe: [op] }
+// { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: [op] }], pipe: [op] }
 const OP_FUNCTION_DEFINITION = JPLOP("fun")
 
 // { ifs: [{ if: function, then: function }], else: function }
@@ -68,6 +83,16 @@ const OP_IF = JPLOP("if")
 // { interpolations: [{ before: string, pipe: [op] }], after: string }
 const OP_INTERPOLATED_STRING = JPLOP(`"$"`)
 
+// { name: string, pipe: function }
+//
+// { name: string, pipe: [op] }
+const OP_LABEL = JPLOP("lbl")
+
+// { pipe: function, cases: [{ pattern: opd, if: function, then: function }], else: function }
+//
+// { pipe: [op], cases: [{ pattern: opd, if: [op], then: [op] }], else: [op] }
+const OP_MATCH = JPLOP("mat")
+
 // {}
 //
 // {}
@@ -88,9 +113,9 @@ const OP_NULL_COALESCENCE = JPLOP("??")
 // { number: number }
 const OP_NUMBER = JPLOP("nbr")
 
-// { fields: [{ key: function, value: function, optional: boolean }] }
+// { fields: [{ key: function, value: function, optional: boolean, spread: boolean }] }
 //
-// { fields: [{ key: [op], value: [op], optional: boolean }] }
+// { fields: [{ key: [op], value: [op], optional: boolean, spread: boolean }] }
 const OP_OBJECT_CONSTRUCTOR = JPLOP("{}")
 
 // { pipes: [function] }
@@ -103,14 +128,24 @@ const OP_OR = JPLOP("or")
 // { pipes: [[op]] }
 const OP_OUTPUT_CONCAT = JPLOP(",")
 
+// { string: string, flags: string }
+//
+// { string: string, flags: string }
+const OP_REGEX = JPLOP("//")
+
+// { pipe: function }
+//
+// { pipe: [op] }
+const OP_SPREAD = JPLOP("...")
+
 // { string: string }
 //
 // { string: string }
 const OP_STRING = JPLOP(`""`)
 
-// { try: function, catch: function }
+// { try: function, catch: function } | { try: function, catches: [{ kinds: [string], when: function, raw: boolean, pipe: function }] }
 //
-// { try: [op], catch: [op] }
+// { try: [op], catch: [op] } | { try: [op], catches: [{ kinds: [string], when: [op], raw: boolean, pipe: [op] }] }
 const OP_TRY = JPLOP("try")
 
 // { name: string }
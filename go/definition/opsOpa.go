@@ -24,6 +24,13 @@ const OPA_FUNCTION = JPLOPA("$()")
 // { optional: boolean }
 const OPA_ITER = JPLOPA("$[]")
 
+// Sub operator for OP_ACCESS
+//
+// { pipe: function | null, optional: boolean }
+//
+// { pipe: [op] | null, optional: boolean }
+const OPA_RECURSIVE_DESCENT = JPLOPA("..")
+
 // Sub operator for OP_ACCESS
 //
 // { from: function, to: function, optional: boolean }
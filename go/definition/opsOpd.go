@@ -0,0 +1,39 @@
+package definition
+
+// JPL sub operator type for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+type JPLOPD string
+
+// Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+//
+// { entries: [{ pattern: opd, default: function, optional: boolean }], rest: string }
+//
+// { entries: [{ pattern: opd, default: [op], optional: boolean }], rest: string }
+const OPD_ARRAY = JPLOPD("[]")
+
+// Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+//
+// { entries: [{ key: function, pattern: opd, default: function, optional: boolean }], rest: string }
+//
+// { entries: [{ key: [op], pattern: opd, default: [op], optional: boolean }], rest: string }
+const OPD_OBJECT = JPLOPD("{}")
+
+// Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+//
+// { type: string, pattern: opd }
+//
+// { type: string, pattern: opd }
+const OPD_TYPE = JPLOPD("is")
+
+// Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+//
+// { pipe: function }
+//
+// { pipe: [op] }
+const OPD_VALUE = JPLOPD("==")
+
+// Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+//
+// { name: string }
+//
+// { name: string }
+const OPD_VARIABLE = JPLOPD("$")
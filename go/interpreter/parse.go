@@ -7,10 +7,17 @@ import (
 
 	"github.com/jplorg/jpl/go/definition"
 	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
 )
 
 type ParserContext struct {
 	Interpreter jpl.JPLInterpreter
+
+	// Words that are reserved in addition to the general reserved terms
+	reserved []string
+
+	// Whether the operands of output concatenations may be spread, which is the case for the contents of array constructors
+	spread bool
 }
 
 // Parse a single program at i.
@@ -42,46 +49,44 @@ func parseProgram(src string, i int, c *ParserContext) (n int, result definition
 	return opPipe(src, n, c)
 }
 
-// Parse function header at i
-func parseFunctionHeader(src string, i int, c *ParserContext) (n int, argNames []string, err jpl.JPLSyntaxError) {
+// Parse function header at i.
+// The typed arguments are only returned if at least one argument is annotated.
+func parseFunctionHeader(src string, i int, c *ParserContext) (n int, argNames []string, args []definition.JPLArgument, err jpl.JPLSyntaxError) {
 	n = i
 
 	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "("})
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, err
 	}
 	n = iM
 	if !isM {
-		return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+		return 0, nil, nil, errorUnexpectedToken(src, n, c, errorOptions{
 			Operator: "function definition",
 			Message:  "expected '('",
 		})
 	}
 
+	typed := false
 	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ")"})
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, err
 	}
 	if isM {
 		n = iM
 	} else {
 		for {
-			iV, isV, name, _, err := safeVariable(src, n, c)
-			if err != nil {
-				return 0, nil, err
+			var arg definition.JPLArgument
+			var isA bool
+			if n, isA, arg, err = parseFunctionArgument(src, n, c); err != nil {
+				return 0, nil, nil, err
 			}
-			if !isV {
-				return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
-					Operator: "function definition",
-					Message:  "expected argument name",
-				})
-			}
-			n = iV
-			argNames = append(argNames, name)
+			argNames = append(argNames, arg.Name)
+			args = append(args, arg)
+			typed = typed || isA
 
 			iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ")"})
 			if err != nil {
-				return 0, nil, err
+				return 0, nil, nil, err
 			}
 			if isM {
 				n = iM
@@ -90,11 +95,11 @@ func parseFunctionHeader(src string, i int, c *ParserContext) (n int, argNames [
 
 			iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ","})
 			if err != nil {
-				return 0, nil, err
+				return 0, nil, nil, err
 			}
 			n = iM
 			if !isM {
-				return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+				return 0, nil, nil, errorUnexpectedToken(src, n, c, errorOptions{
 					Operator: "function definition",
 					Message:  "expected ',' or ')'",
 				})
@@ -104,17 +109,101 @@ func parseFunctionHeader(src string, i int, c *ParserContext) (n int, argNames [
 
 	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ":"})
 	if err != nil {
-		return 0, nil, err
+		return 0, nil, nil, err
 	}
 	n = iM
 	if !isM {
-		return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+		return 0, nil, nil, errorUnexpectedToken(src, n, c, errorOptions{
 			Operator: "function definition",
 			Message:  "expected ':'",
 		})
 	}
 
-	return n, argNames, nil
+	if !typed {
+		args = nil
+	}
+	return n, argNames, args, nil
+}
+
+// Parse function argument at i, which may be annotated with an optional marker, types and a default value.
+// isA reports whether the argument has any annotations.
+func parseFunctionArgument(src string, i int, c *ParserContext) (n int, isA bool, result definition.JPLArgument, err jpl.JPLSyntaxError) {
+	n = i
+
+	iV, isV, name, _, err := safeVariable(src, n, c)
+	if err != nil {
+		return 0, false, result, err
+	}
+	if !isV {
+		return 0, false, result, errorUnexpectedToken(src, n, c, errorOptions{
+			Operator: "function definition",
+			Message:  "expected argument name",
+		})
+	}
+	n = iV
+	result.Name = name
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "?"})
+	if err != nil {
+		return 0, false, result, err
+	}
+	if isM {
+		n = iM
+		result.Optional = true
+		isA = true
+	}
+
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ":"})
+	if err != nil {
+		return 0, false, result, err
+	}
+	if isM {
+		n = iM
+		isA = true
+
+		for {
+			// Types are not variables, so reserved words like `null` are valid here
+			iV, isV, t, err := variable(src, n, c)
+			if err != nil {
+				return 0, false, result, err
+			}
+			if !isV {
+				return 0, false, result, errorUnexpectedToken(src, n, c, errorOptions{
+					Operator: "function definition",
+					Message:  "expected argument type",
+				})
+			}
+
+			switch jpl.JPLDataType(t) {
+			case jpl.JPLT_NULL, jpl.JPLT_BOOLEAN, jpl.JPLT_NUMBER, jpl.JPLT_STRING, jpl.JPLT_ARRAY, jpl.JPLT_OBJECT, jpl.JPLT_FUNCTION:
+
+			default:
+				return 0, false, result, errorGeneric(src, n, c, errorOptions{Operator: "function definition", Message: "unknown type " + t})
+			}
+			n = iV
+			result.Types = append(result.Types, t)
+
+			iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "|", NotBeforeSet: "|="})
+			if err != nil {
+				return 0, false, result, err
+			}
+			if !isM {
+				break
+			}
+			n = iM
+		}
+	}
+
+	var ops definition.Pipe
+	if n, ops, err = parsePatternDefault(src, n, c); err != nil {
+		return 0, false, result, err
+	}
+	if ops != nil {
+		result.Default = ops
+		isA = true
+	}
+
+	return n, isA, result, nil
 }
 
 type accessOptions struct {
@@ -127,7 +216,73 @@ func parseAccess(src string, i int, c *ParserContext, options accessOptions) (n
 
 	canAssign = true
 	for {
-		iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "."})
+		iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "..", NotBeforeSet: "."})
+		if err != nil {
+			return 0, false, nil, false, err
+		}
+		if isM {
+			n = iM
+
+			var opsField definition.Pipe
+			iV, isV, name, _, err := safeVariable(src, n, c)
+			if err != nil {
+				return 0, false, nil, false, err
+			}
+			if isV {
+				n = iV
+				opsField = definition.Pipe{{OP: definition.OP_STRING, Params: definition.JPLInstructionParams{String: name}}}
+			} else {
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "["})
+				if err != nil {
+					return 0, false, nil, false, err
+				}
+				if !isM {
+					return 0, false, nil, false, errorUnexpectedToken(src, n, c, errorOptions{
+						Operator: "recursive descent operator",
+						Message:  "expected field name or '['",
+					})
+				}
+				n = iM
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "]"})
+				if err != nil {
+					return 0, false, nil, false, err
+				}
+				if !isM {
+					if n, opsField, err = opPipe(src, n, c); err != nil {
+						return 0, false, nil, false, err
+					}
+
+					iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "]"})
+					if err != nil {
+						return 0, false, nil, false, err
+					}
+					n = iM
+					if !isM {
+						return 0, false, nil, false, errorUnexpectedToken(src, n, c, errorOptions{
+							Operator: "recursive descent operator",
+							Message:  "expected ']'",
+						})
+					}
+				} else {
+					n = iM
+				}
+			}
+
+			var optional bool
+			iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "?", NotBeforeSet: "?="})
+			if err != nil {
+				return 0, false, nil, false, err
+			}
+			if isM {
+				n = iM
+				optional = true
+			}
+			selectors = append(selectors, definition.JPLSelector{OP: definition.OPA_RECURSIVE_DESCENT, Params: definition.JPLSelectorParams{Pipe: opsField, Optional: optional}})
+			continue
+		}
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "."})
 		if err != nil {
 			return 0, false, nil, false, err
 		}
@@ -531,120 +686,654 @@ func parseAssignment(src string, i int, c *ParserContext) (n int, is bool, assig
 	return n, false, nil, nil
 }
 
-// Parse number at i
-func parseNumber(src string, i int, c *ParserContext) (n int, is bool, result definition.Pipe, err jpl.JPLSyntaxError) {
+// Parse pattern at i, which is used for destructuring values
+func parsePattern(src string, i int, c *ParserContext) (n int, is bool, pattern *definition.JPLPattern, err jpl.JPLSyntaxError) {
 	n = i
-	var value string
 
-	for {
-		iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: "0123456789"})
-		if !isSet {
-			break
-		}
-		n = iSet
-		is = true
-		value += valueSet
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "true", SpaceAfter: true})
+	if err != nil {
+		return 0, false, nil, err
 	}
+	if isM {
+		n = iM
 
-	if !is {
-		return n, false, nil, nil
+		return n, true, &definition.JPLPattern{OP: definition.OPD_VALUE, Params: definition.JPLPatternParams{Pipe: definition.Pipe{{OP: definition.OP_CONSTANT_TRUE}}}}, nil
 	}
 
-	iM, isM := match(src, n, c, matchOptions{Phrase: "."})
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "false", SpaceAfter: true})
+	if err != nil {
+		return 0, false, nil, err
+	}
 	if isM {
 		n = iM
-		value += "."
 
-		for {
-			iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: "0123456789"})
-			if !isSet {
-				break
-			}
-			n = iSet
-			value += valueSet
-		}
+		return n, true, &definition.JPLPattern{OP: definition.OPD_VALUE, Params: definition.JPLPatternParams{Pipe: definition.Pipe{{OP: definition.OP_CONSTANT_FALSE}}}}, nil
 	}
 
-	iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: "eE"})
-	if isSet {
-		n = iSet
-		value += valueSet
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "null", SpaceAfter: true})
+	if err != nil {
+		return 0, false, nil, err
+	}
+	if isM {
+		n = iM
 
-		iSet, isSet, valueSet = matchSet(src, n, c, matchSetOptions{Set: "+-"})
-		if isSet {
-			n = iSet
-			value += valueSet
-		}
+		return n, true, &definition.JPLPattern{OP: definition.OPD_VALUE, Params: definition.JPLPatternParams{Pipe: definition.Pipe{{OP: definition.OP_CONSTANT_NULL}}}}, nil
+	}
 
-		var isE bool
-		for {
-			iSet, isSet, valueSet = matchSet(src, n, c, matchSetOptions{Set: "0123456789"})
-			if !isSet {
-				break
-			}
-			n = iSet
-			isE = true
-			value += valueSet
-		}
-		if !isE {
-			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "number", Message: "expected digit"})
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "-"})
+	if err != nil {
+		return 0, false, nil, err
+	}
+	iN, isN, opsNumber, err := parseNumber(src, iM, c)
+	if err != nil {
+		return 0, false, nil, err
+	}
+	if isN {
+		n = iN
+
+		if isM {
+			opsNumber = append(opsNumber, definition.JPLInstruction{OP: definition.OP_NEGATION})
 		}
+		return n, true, &definition.JPLPattern{OP: definition.OPD_VALUE, Params: definition.JPLPatternParams{Pipe: opsNumber}}, nil
 	}
 
-	var number float64
-	if parsed, err := strconv.ParseFloat(value, 64); err != nil {
-		return 0, false, nil, errorGeneric(src, n, c, errorOptions{Operator: "number", Message: err.Error()})
-	} else {
-		number = parsed
+	iS, isS, opsString, err := parseString(src, n, c)
+	if err != nil {
+		return 0, false, nil, err
 	}
+	if isS {
+		n = iS
 
-	if n, _, err = walkWhitespace(src, n, c); err != nil {
+		return n, true, &definition.JPLPattern{OP: definition.OPD_VALUE, Params: definition.JPLPatternParams{Pipe: opsString}}, nil
+	}
+
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "("})
+	if err != nil {
 		return 0, false, nil, err
 	}
+	if isM {
+		n = iM
 
-	return n, true, definition.Pipe{{OP: definition.OP_NUMBER, Params: definition.JPLInstructionParams{Number: number}}}, nil
-}
+		var ops definition.Pipe
+		if n, ops, err = opPipe(src, n, c); err != nil {
+			return 0, false, nil, err
+		}
 
-// Parse string at i
-func parseString(src string, i int, c *ParserContext) (n int, is bool, result definition.Pipe, err jpl.JPLSyntaxError) {
-	n = i
-	var value []byte
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ")"})
+		if err != nil {
+			return 0, false, nil, err
+		}
+		n = iM
+		if !isM {
+			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected ')'"})
+		}
 
-	iSet, isSet, boundary := matchSet(src, n, c, matchSetOptions{Set: "\"'`"})
-	if !isSet {
-		return n, false, nil, nil
+		return n, true, &definition.JPLPattern{OP: definition.OPD_VALUE, Params: definition.JPLPatternParams{Pipe: ops}}, nil
 	}
-	n = iSet
 
-	multilineString := boundary == "`"
+	iV, isV, name, _, err := safeVariable(src, n, c)
+	if err != nil {
+		return 0, false, nil, err
+	}
+	if isV {
+		n = iV
 
-	var interpolations []definition.JPLInterpolation
-	for {
-		if iM, isM := match(src, n, c, matchOptions{Phrase: boundary}); isM {
-			n = iM
-			break
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "("})
+		if err != nil {
+			return 0, false, nil, err
 		}
-
-		if iEnd, isEnd := eot(src, n, c); isEnd {
-			n = iEnd
-			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{
-				Operator: "string",
-				Message:  "incomplete string literal",
-			})
+		if !isM {
+			return n, true, &definition.JPLPattern{OP: definition.OPD_VARIABLE, Params: definition.JPLPatternParams{Name: name}}, nil
 		}
 
-		if iM, isM := match(src, n, c, matchOptions{Phrase: "\\"}); isM {
-			n = iM
+		switch jpl.JPLDataType(name) {
+		case jpl.JPLT_BOOLEAN, jpl.JPLT_NUMBER, jpl.JPLT_STRING, jpl.JPLT_ARRAY, jpl.JPLT_OBJECT, jpl.JPLT_FUNCTION:
 
-			iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "("})
-			if err != nil {
-				return 0, false, nil, err
-			}
-			if isM {
-				n = iM
+		default:
+			return 0, false, nil, errorGeneric(src, n, c, errorOptions{Operator: "pattern", Message: "unknown type " + name})
+		}
+		n = iM
 
-				var ops definition.Pipe
-				if n, ops, err = opPipe(src, n, c); err != nil {
+		iP, isP, opsPattern, err := parsePattern(src, n, c)
+		if err != nil {
+			return 0, false, nil, err
+		}
+		n = iP
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ")"})
+		if err != nil {
+			return 0, false, nil, err
+		}
+		n = iM
+		if !isM {
+			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected ')'"})
+		}
+
+		params := definition.JPLPatternParams{Type: name}
+		if isP {
+			params.Pattern = opsPattern
+		}
+		return n, true, &definition.JPLPattern{OP: definition.OPD_TYPE, Params: params}, nil
+	}
+
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "{"})
+	if err != nil {
+		return 0, false, nil, err
+	}
+	if isM {
+		n = iM
+
+		var entries []definition.JPLPatternEntry
+		var rest string
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "}"})
+		if err != nil {
+			return 0, false, nil, err
+		}
+		if isM {
+			n = iM
+		} else {
+			for {
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "..."})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				if isM {
+					n = iM
+
+					if n, rest, err = parsePatternRest(src, n, c, "}"); err != nil {
+						return 0, false, nil, err
+					}
+					break
+				}
+
+				var entry definition.JPLPatternEntry
+				var shorthand string
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "("})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				if isM {
+					n = iM
+
+					if n, entry.Key, err = opPipe(src, n, c); err != nil {
+						return 0, false, nil, err
+					}
+
+					iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ")"})
+					if err != nil {
+						return 0, false, nil, err
+					}
+					n = iM
+					if !isM {
+						return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected ')'"})
+					}
+				} else {
+					iS, isS, opsKey, err := parseString(src, n, c)
+					if err != nil {
+						return 0, false, nil, err
+					}
+					if isS {
+						n = iS
+						entry.Key = opsKey
+					} else {
+						iV, isV, name, _, err := safeVariable(src, n, c)
+						if err != nil {
+							return 0, false, nil, err
+						}
+						if !isV {
+							return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected field"})
+						}
+						n = iV
+
+						entry.Key = definition.Pipe{{OP: definition.OP_STRING, Params: definition.JPLInstructionParams{String: name}}}
+						shorthand = name
+					}
+				}
+
+				if n, entry.Optional, err = matchWord(src, n, c, matchOptions{Phrase: "?"}); err != nil {
+					return 0, false, nil, err
+				}
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ":"})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				if isM {
+					n = iM
+
+					iP, isP, opsPattern, err := parsePattern(src, n, c)
+					if err != nil {
+						return 0, false, nil, err
+					}
+					n = iP
+					if !isP {
+						return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected pattern"})
+					}
+					entry.Pattern = *opsPattern
+				} else if shorthand != "" {
+					entry.Pattern = definition.JPLPattern{OP: definition.OPD_VARIABLE, Params: definition.JPLPatternParams{Name: shorthand}}
+				} else {
+					return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected ':'"})
+				}
+
+				if n, entry.Default, err = parsePatternDefault(src, n, c); err != nil {
+					return 0, false, nil, err
+				}
+
+				entries = append(entries, entry)
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "}"})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				if isM {
+					n = iM
+					break
+				}
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ","})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				n = iM
+				if !isM {
+					return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected ',' or '}'"})
+				}
+			}
+		}
+
+		return n, true, &definition.JPLPattern{OP: definition.OPD_OBJECT, Params: definition.JPLPatternParams{Entries: entries, Rest: rest}}, nil
+	}
+
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "["})
+	if err != nil {
+		return 0, false, nil, err
+	}
+	if isM {
+		n = iM
+
+		var entries []definition.JPLPatternEntry
+		var rest string
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "]"})
+		if err != nil {
+			return 0, false, nil, err
+		}
+		if isM {
+			n = iM
+		} else {
+			for {
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "..."})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				if isM {
+					n = iM
+
+					if n, rest, err = parsePatternRest(src, n, c, "]"); err != nil {
+						return 0, false, nil, err
+					}
+					break
+				}
+
+				var entry definition.JPLPatternEntry
+
+				iP, isP, opsPattern, err := parsePattern(src, n, c)
+				if err != nil {
+					return 0, false, nil, err
+				}
+				n = iP
+				if !isP {
+					return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected pattern"})
+				}
+				entry.Pattern = *opsPattern
+
+				if n, entry.Optional, err = matchWord(src, n, c, matchOptions{Phrase: "?"}); err != nil {
+					return 0, false, nil, err
+				}
+
+				if n, entry.Default, err = parsePatternDefault(src, n, c); err != nil {
+					return 0, false, nil, err
+				}
+
+				entries = append(entries, entry)
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "]"})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				if isM {
+					n = iM
+					break
+				}
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ","})
+				if err != nil {
+					return 0, false, nil, err
+				}
+				n = iM
+				if !isM {
+					return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected ',' or ']'"})
+				}
+			}
+		}
+
+		return n, true, &definition.JPLPattern{OP: definition.OPD_ARRAY, Params: definition.JPLPatternParams{Entries: entries, Rest: rest}}, nil
+	}
+
+	return i, false, nil, nil
+}
+
+// Parse the name of a pattern's rest at i, which must be followed by end
+func parsePatternRest(src string, i int, c *ParserContext, end string) (n int, rest string, err jpl.JPLSyntaxError) {
+	n = i
+
+	iV, isV, name, _, err := safeVariable(src, n, c)
+	if err != nil {
+		return 0, "", err
+	}
+	if !isV {
+		return 0, "", errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected variable name"})
+	}
+	n = iV
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: end})
+	if err != nil {
+		return 0, "", err
+	}
+	n = iM
+	if !isM {
+		return 0, "", errorUnexpectedToken(src, n, c, errorOptions{Operator: "pattern", Message: "expected '" + end + "' after rest"})
+	}
+
+	return n, name, nil
+}
+
+// Parse the default value of a pattern entry at i, if present
+func parsePatternDefault(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "=", NotBeforeSet: "="})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return n, nil, nil
+	}
+	n = iM
+
+	return opSubPipe(src, n, c)
+}
+
+// Parse destructuring definition at i, which is a pattern followed by an assignment.
+// Other constructs starting like a pattern, e.g. object constructors, are not reported unless they are followed by an assignment.
+func parseDestructuringDefinition(src string, i int, c *ParserContext) (n int, is bool, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iP, isP, pattern, errP := parsePattern(src, n, c)
+	if errP != nil {
+		// The construct may still be a valid value, e.g. an array constructor containing spread operands.
+		// The pattern's error is only reported if that value is followed by an assignment.
+		iV, _, errV := opObjectConstructor(src, n, c)
+		if errV != nil {
+			return i, false, nil, nil
+		}
+		_, isM, err := matchWord(src, iV, c, matchOptions{Phrase: "=", NotBeforeSet: "="})
+		if err != nil {
+			return 0, false, nil, err
+		}
+		if isM {
+			return 0, false, nil, errP
+		}
+		return i, false, nil, nil
+	}
+	if !isP || (pattern.OP != definition.OPD_OBJECT && pattern.OP != definition.OPD_ARRAY) {
+		return i, false, nil, nil
+	}
+	n = iP
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "=", NotBeforeSet: "="})
+	if err != nil {
+		return 0, false, nil, err
+	}
+	if !isM {
+		return i, false, nil, nil
+	}
+	n = iM
+
+	var ops definition.Pipe
+	if n, ops, err = opSubRoute(src, n, c); err != nil {
+		return 0, false, nil, err
+	}
+
+	return n, true, definition.Pipe{{OP: definition.OP_DESTRUCTURING_DEFINITION, Params: definition.JPLInstructionParams{Pattern: pattern, Pipe: ops}}}, nil
+}
+
+// Parse label header at i, which is `label name |`.
+// `label` is not reserved, so it is only considered to be a label if it is followed by a name and a pipe.
+func parseLabel(src string, i int, c *ParserContext) (n int, is bool, name string, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "label", SpaceAfter: true})
+	if err != nil {
+		return 0, false, "", err
+	}
+	if !isM {
+		return i, false, "", nil
+	}
+	n = iM
+
+	iV, isV, name, _, err := safeVariable(src, n, c)
+	if err != nil {
+		return 0, false, "", err
+	}
+	if !isV {
+		return i, false, "", nil
+	}
+	n = iV
+
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "|", NotBeforeSet: "="})
+	if err != nil {
+		return 0, false, "", err
+	}
+	if !isM {
+		return i, false, "", nil
+	}
+	n = iM
+
+	return n, true, name, nil
+}
+
+// Parse path at i, which is a value access that could also be used as an assignment target
+func parsePath(src string, i int, c *ParserContext, operator string) (n int, selectors []definition.JPLSelector, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: ".", NotBeforeSet: "."})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		// Recursive descents are parsed as part of the access
+		_, isD, err := matchWord(src, n, c, matchOptions{Phrase: "..", NotBeforeSet: "."})
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isD {
+			return 0, nil, errorUnexpectedToken(src, iM, c, errorOptions{
+				Operator: operator,
+				Message:  "expected path",
+			})
+		}
+	} else {
+		n = iM
+	}
+
+	iV, isV, name, _, err := safeVariable(src, n, c)
+	if err != nil {
+		return 0, nil, err
+	}
+	if isM && isV {
+		n = iV
+
+		var optional bool
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "?", NotBeforeSet: "?="})
+		if err != nil {
+			return 0, nil, err
+		}
+		if isM {
+			n = iM
+			optional = true
+		}
+
+		selectors = append(selectors, definition.JPLSelector{
+			OP:     definition.OPA_FIELD,
+			Params: definition.JPLSelectorParams{Pipe: definition.Pipe{{OP: definition.OP_STRING, Params: definition.JPLInstructionParams{String: name}}}, Optional: optional},
+		})
+	}
+
+	iAc, isAc, selectorsAc, canAssign, err := parseAccess(src, n, c, accessOptions{Identity: len(selectors) == 0})
+	if err != nil {
+		return 0, nil, err
+	}
+	if isAc {
+		if !canAssign {
+			return 0, nil, errorUnexpectedToken(src, iAc, c, errorOptions{
+				Operator: operator,
+				Message:  "function calls cannot be part of a path",
+			})
+		}
+		n = iAc
+		selectors = append(selectors, selectorsAc...)
+	}
+
+	return n, selectors, nil
+}
+
+// Parse number at i
+func parseNumber(src string, i int, c *ParserContext) (n int, is bool, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+	var value string
+
+	for {
+		iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: "0123456789"})
+		if !isSet {
+			break
+		}
+		n = iSet
+		is = true
+		value += valueSet
+	}
+
+	if !is {
+		return n, false, nil, nil
+	}
+
+	iM, isM := match(src, n, c, matchOptions{Phrase: "."})
+	if isM {
+		n = iM
+		value += "."
+
+		for {
+			iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: "0123456789"})
+			if !isSet {
+				break
+			}
+			n = iSet
+			value += valueSet
+		}
+	}
+
+	iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: "eE"})
+	if isSet {
+		n = iSet
+		value += valueSet
+
+		iSet, isSet, valueSet = matchSet(src, n, c, matchSetOptions{Set: "+-"})
+		if isSet {
+			n = iSet
+			value += valueSet
+		}
+
+		var isE bool
+		for {
+			iSet, isSet, valueSet = matchSet(src, n, c, matchSetOptions{Set: "0123456789"})
+			if !isSet {
+				break
+			}
+			n = iSet
+			isE = true
+			value += valueSet
+		}
+		if !isE {
+			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "number", Message: "expected digit"})
+		}
+	}
+
+	var number float64
+	if parsed, err := strconv.ParseFloat(value, 64); err != nil {
+		return 0, false, nil, errorGeneric(src, n, c, errorOptions{Operator: "number", Message: err.Error()})
+	} else {
+		number = parsed
+	}
+
+	if n, _, err = walkWhitespace(src, n, c); err != nil {
+		return 0, false, nil, err
+	}
+
+	return n, true, definition.Pipe{{OP: definition.OP_NUMBER, Params: definition.JPLInstructionParams{Number: number}}}, nil
+}
+
+// Parse string at i
+func parseString(src string, i int, c *ParserContext) (n int, is bool, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+	var value []byte
+
+	// Raw strings do not support escape sequences or interpolations
+	var raw bool
+	if iM, isM := match(src, n, c, matchOptions{Phrase: "r"}); isM {
+		if _, isSet, _ := matchSet(src, iM, c, matchSetOptions{Set: setStringBoundary}); isSet {
+			n = iM
+			raw = true
+		}
+	}
+
+	iSet, isSet, boundary := matchSet(src, n, c, matchSetOptions{Set: setStringBoundary})
+	if !isSet {
+		return i, false, nil, nil
+	}
+	n = iSet
+
+	multilineString := boundary == "`"
+
+	var interpolations []definition.JPLInterpolation
+	for {
+		if iM, isM := match(src, n, c, matchOptions{Phrase: boundary}); isM {
+			n = iM
+			break
+		}
+
+		if iEnd, isEnd := eot(src, n, c); isEnd {
+			n = iEnd
+			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{
+				Operator: "string",
+				Message:  "incomplete string literal",
+			})
+		}
+
+		if iM, isM := match(src, n, c, matchOptions{Phrase: "\\"}); isM && !raw {
+			n = iM
+
+			iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "("})
+			if err != nil {
+				return 0, false, nil, err
+			}
+			if isM {
+				n = iM
+
+				var ops definition.Pipe
+				if n, ops, err = opPipe(src, n, c); err != nil {
 					return 0, false, nil, err
 				}
 
@@ -823,12 +1512,113 @@ func parseString(src string, i int, c *ParserContext) (n int, is bool, result de
 	return n, true, definition.Pipe{{OP: definition.OP_INTERPOLATED_STRING, Params: definition.JPLInstructionParams{Interpolations: interpolations, After: string(value)}}}, nil
 }
 
+// Parse regular expression at i
+func parseRegex(src string, i int, c *ParserContext) (n int, is bool, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+	var pattern []byte
+
+	iM, isM := match(src, n, c, matchOptions{Phrase: "/"})
+	if !isM {
+		return n, false, nil, nil
+	}
+	n = iM
+
+	// Slashes inside of character classes do not need to be escaped
+	var class bool
+	for {
+		if iEnd, isEnd := eot(src, n, c); isEnd {
+			n = iEnd
+			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{
+				Operator: "regular expression",
+				Message:  "incomplete regular expression literal",
+			})
+		}
+
+		if src[n] < 0x20 {
+			return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "regular expression"})
+		}
+
+		if src[n] == '/' && !class {
+			n += 1
+			break
+		}
+
+		if src[n] == '\\' {
+			n += 1
+			if iEnd, isEnd := eot(src, n, c); isEnd {
+				n = iEnd
+				return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{
+					Operator: "regular expression",
+					Message:  "incomplete regular expression literal",
+				})
+			}
+			if src[n] < 0x20 {
+				return 0, false, nil, errorUnexpectedToken(src, n, c, errorOptions{Operator: "regular expression"})
+			}
+
+			// Escaped slashes are only escaped for the literal and not for the expression itself
+			if src[n] != '/' {
+				pattern = append(pattern, '\\')
+			}
+			pattern = append(pattern, src[n])
+			n += 1
+			continue
+		}
+
+		switch src[n] {
+		case '[':
+			class = true
+
+		case ']':
+			class = false
+
+		default:
+		}
+
+		pattern = append(pattern, src[n])
+		n += 1
+	}
+
+	var flags string
+	for {
+		iSet, isSet, valueSet := matchSet(src, n, c, matchSetOptions{Set: setAZ})
+		if !isSet {
+			break
+		}
+		n = iSet
+		flags += valueSet
+	}
+
+	if _, err := library.CompileRegex(string(pattern), flags); err != nil {
+		return 0, false, nil, errorGeneric(src, i, c, errorOptions{Operator: "regular expression", Message: err.Error()})
+	}
+
+	if n, _, err = walkWhitespace(src, n, c); err != nil {
+		return 0, false, nil, err
+	}
+
+	return n, true, definition.Pipe{{OP: definition.OP_REGEX, Params: definition.JPLInstructionParams{String: string(pattern), Flags: flags}}}, nil
+}
+
 // Parse pipe at i
 func opPipe(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
 	n = i
 
 	var pipe definition.Pipe
 	for {
+		iL, isL, name, err := parseLabel(src, n, c)
+		if err != nil {
+			return 0, nil, err
+		}
+		if isL {
+			var ops definition.Pipe
+			if n, ops, err = opPipe(src, iL, c); err != nil {
+				return 0, nil, err
+			}
+			pipe = append(pipe, definition.JPLInstruction{OP: definition.OP_LABEL, Params: definition.JPLInstructionParams{Name: name, Pipe: ops}})
+			break
+		}
+
 		var ops definition.Pipe
 		if n, ops, err = opOutputConcat(src, n, c); err != nil {
 			return 0, nil, err
@@ -854,6 +1644,19 @@ func opSubPipe(src string, i int, c *ParserContext) (n int, result definition.Pi
 
 	var pipe definition.Pipe
 	for {
+		iL, isL, name, err := parseLabel(src, n, c)
+		if err != nil {
+			return 0, nil, err
+		}
+		if isL {
+			var ops definition.Pipe
+			if n, ops, err = opSubPipe(src, iL, c); err != nil {
+				return 0, nil, err
+			}
+			pipe = append(pipe, definition.JPLInstruction{OP: definition.OP_LABEL, Params: definition.JPLInstructionParams{Name: name, Pipe: ops}})
+			break
+		}
+
 		var ops definition.Pipe
 		if n, ops, err = opTry(src, n, c); err != nil {
 			return 0, nil, err
@@ -882,17 +1685,142 @@ func opSubRoute(src string, i int, c *ParserContext) (n int, result definition.P
 func opOutputConcat(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
 	n = i
 
+	// Operands are only spread directly, so nested contexts do not allow spreading
+	operandContext := c
+	if c.spread {
+		nestedContext := *c
+		nestedContext.spread = false
+		operandContext = &nestedContext
+	}
+
 	var pipes []definition.Pipe
 	for {
+		var isSpread bool
+		if c.spread {
+			iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "..."})
+			if err != nil {
+				return 0, nil, err
+			}
+			if isM {
+				n = iM
+				isSpread = true
+			}
+		}
+
 		var ops definition.Pipe
-		if n, ops, err = opTry(src, n, c); err != nil {
+		if n, ops, err = opTry(src, n, operandContext); err != nil {
+			return 0, nil, err
+		}
+		if isSpread {
+			ops = definition.Pipe{{OP: definition.OP_SPREAD, Params: definition.JPLInstructionParams{Pipe: ops}}}
+		}
+		pipes = append(pipes, ops)
+
+		iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: ","})
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isM {
+			break
+		}
+		n = iM
+	}
+
+	if len(pipes) == 1 {
+		return n, pipes[0], nil
+	}
+
+	return n, definition.Pipe{{OP: definition.OP_OUTPUT_CONCAT, Params: definition.JPLInstructionParams{Pipes: pipes}}}, nil
+}
+
+// Parse try at i
+func opTry(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "try", SpaceAfter: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return opOr(src, n, c)
+	}
+	n = iM
+
+	var opsTry definition.Pipe
+	if n, opsTry, err = opOr(src, n, c); err != nil {
+		return 0, nil, err
+	}
+
+	var catches []definition.JPLCatch
+	var opsCatch definition.Pipe
+	hasCatch := false
+	for {
+		iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "catch", SpaceAfter: true})
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isM {
+			break
+		}
+		n = iM
+
+		iH, isH, clause, err := parseCatchHead(src, n, c)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isH {
+			// A catch clause without a head catches all remaining errors and must therefore be the last one
+			if n, opsCatch, err = opOr(src, n, c); err != nil {
+				return 0, nil, err
+			}
+			hasCatch = true
+			break
+		}
+		n = iH
+
+		if n, clause.Pipe, err = opOr(src, n, c); err != nil {
 			return 0, nil, err
 		}
-		pipes = append(pipes, ops)
+		catches = append(catches, clause)
+	}
+
+	if catches == nil {
+		if !hasCatch {
+			opsCatch = definition.Pipe{{OP: definition.OP_VOID}}
+		}
+		return n, definition.Pipe{{OP: definition.OP_TRY, Params: definition.JPLInstructionParams{Try: opsTry, Catch: opsCatch}}}, nil
+	}
+
+	if hasCatch {
+		catches = append(catches, definition.JPLCatch{Raw: true, Pipe: opsCatch})
+	}
+	return n, definition.Pipe{{OP: definition.OP_TRY, Params: definition.JPLInstructionParams{Try: opsTry, Catches: catches}}}, nil
+}
+
+// Parse catch clause head at i, which consists of error kinds and an optional condition, followed by ':'.
+// Other constructs, e.g. the expression of a catch-all clause, are not reported.
+func parseCatchHead(src string, i int, c *ParserContext) (n int, is bool, result definition.JPLCatch, err jpl.JPLSyntaxError) {
+	n = i
+
+	var positions []int
+	for {
+		iV, isV, kind, err := variable(src, n, c)
+		if err != nil {
+			return 0, false, result, err
+		}
+		if !isV || kind == "when" {
+			if len(result.Kinds) > 0 {
+				return i, false, result, nil
+			}
+			break
+		}
+		positions = append(positions, n)
+		result.Kinds = append(result.Kinds, kind)
+		n = iV
 
-		iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: ","})
+		iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "|", NotBeforeSet: "="})
 		if err != nil {
-			return 0, nil, err
+			return 0, false, result, err
 		}
 		if !isM {
 			break
@@ -900,47 +1828,42 @@ func opOutputConcat(src string, i int, c *ParserContext) (n int, result definiti
 		n = iM
 	}
 
-	if len(pipes) == 1 {
-		return n, pipes[0], nil
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "when", SpaceAfter: true})
+	if err != nil {
+		return 0, false, result, err
+	}
+	if isM {
+		iC, opsWhen, errC := opOr(src, iM, c)
+		if errC != nil {
+			return i, false, result, nil
+		}
+		n = iC
+		result.When = opsWhen
 	}
 
-	return n, definition.Pipe{{OP: definition.OP_OUTPUT_CONCAT, Params: definition.JPLInstructionParams{Pipes: pipes}}}, nil
-}
-
-// Parse try at i
-func opTry(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
-	n = i
+	if result.Kinds == nil && result.When == nil {
+		return i, false, result, nil
+	}
 
-	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "try", SpaceAfter: true})
+	iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ":"})
 	if err != nil {
-		return 0, nil, err
+		return 0, false, result, err
 	}
 	if !isM {
-		return opOr(src, n, c)
+		return i, false, result, nil
 	}
 	n = iM
 
-	var opsTry definition.Pipe
-	if n, opsTry, err = opOr(src, n, c); err != nil {
-		return 0, nil, err
-	}
-
-	var opsCatch definition.Pipe
-	iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "catch", SpaceAfter: true})
-	if err != nil {
-		return 0, nil, err
-	}
-	if isM {
-		n = iM
+	for j, kind := range result.Kinds {
+		switch kind {
+		case library.ERROR_KIND_ERROR, library.ERROR_KIND_RUNTIME_ERROR, library.ERROR_KIND_TYPE_ERROR, library.ERROR_KIND_REFERENCE_ERROR, library.ERROR_KIND_ZERO_DIVISION_ERROR, library.ERROR_KIND_TYPE_CONVERSION_ERROR, library.ERROR_KIND_EXECUTION_ERROR:
 
-		if n, opsCatch, err = opOr(src, n, c); err != nil {
-			return 0, nil, err
+		default:
+			return 0, false, result, errorGeneric(src, positions[j], c, errorOptions{Operator: "catch clause", Message: "unknown error kind " + kind})
 		}
-	} else {
-		opsCatch = definition.Pipe{{OP: definition.OP_VOID}}
 	}
 
-	return n, definition.Pipe{{OP: definition.OP_TRY, Params: definition.JPLInstructionParams{Try: opsTry, Catch: opsCatch}}}, nil
+	return n, true, result, nil
 }
 
 // Parse or at i
@@ -1356,7 +2279,7 @@ func opIf(src string, i int, c *ParserContext) (n int, result definition.Pipe, e
 		return 0, nil, err
 	}
 	if !isM {
-		return opConstant(src, n, c)
+		return opMatch(src, n, c)
 	}
 	n = iM
 
@@ -1425,6 +2348,200 @@ func opIf(src string, i int, c *ParserContext) (n int, result definition.Pipe, e
 	return n, definition.Pipe{{OP: definition.OP_IF, Params: definition.JPLInstructionParams{Ifs: ifs, Else: opsElse}}}, nil
 }
 
+// Parse match statement at i
+func opMatch(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "match", SpaceAfter: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return opDelete(src, n, c)
+	}
+
+	// `match` is not reserved, so it is only considered to be a statement if it is followed by a subject and `with`.
+	// In the subject, `with` is reserved, so that e.g. `. with` is not considered to be a field access.
+	subjectContext := *c
+	subjectContext.reserved = append(library.CopySlice(c.reserved), "with")
+	iS, opsSubject, errS := opPipe(src, iM, &subjectContext)
+	if errS != nil {
+		return opDelete(src, n, c)
+	}
+	iM, isM, err = matchWord(src, iS, c, matchOptions{SpaceBefore: true, Phrase: "with", SpaceAfter: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return opDelete(src, n, c)
+	}
+	n = iM
+
+	var cases []definition.JPLMatchCase
+	for {
+		iP, isP, pattern, err := parsePattern(src, n, c)
+		if err != nil {
+			return 0, nil, err
+		}
+		n = iP
+		if !isP {
+			return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+				Operator: "match statement",
+				Message:  "expected pattern",
+			})
+		}
+
+		var opsIf definition.Pipe
+		iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "if", SpaceAfter: true})
+		if err != nil {
+			return 0, nil, err
+		}
+		if isM {
+			n = iM
+
+			if n, opsIf, err = opPipe(src, n, c); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "then", SpaceAfter: true})
+		if err != nil {
+			return 0, nil, err
+		}
+		n = iM
+		if !isM {
+			return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+				Operator: "match statement",
+				Message:  "expected 'then'",
+			})
+		}
+
+		var opsThen definition.Pipe
+		if n, opsThen, err = opPipe(src, n, c); err != nil {
+			return 0, nil, err
+		}
+		cases = append(cases, definition.JPLMatchCase{Pattern: *pattern, If: opsIf, Then: opsThen})
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "with", SpaceAfter: true})
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isM {
+			break
+		}
+		n = iM
+	}
+
+	var opsElse definition.Pipe
+	iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "else", SpaceAfter: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	if isM {
+		n = iM
+
+		if n, opsElse, err = opPipe(src, n, c); err != nil {
+			return 0, nil, err
+		}
+	} else {
+		opsElse = nil
+	}
+
+	iM, isM, err = matchWord(src, n, c, matchOptions{SpaceBefore: true, Phrase: "end"})
+	if err != nil {
+		return 0, nil, err
+	}
+	n = iM
+	if !isM {
+		return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+			Operator: "match statement",
+			Message:  "expected 'end'",
+		})
+	}
+
+	return n, definition.Pipe{{OP: definition.OP_MATCH, Params: definition.JPLInstructionParams{Pipe: opsSubject, Cases: cases, Else: opsElse}}}, nil
+}
+
+// Parse delete statement at i
+func opDelete(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "delete", SpaceAfter: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return opBreak(src, n, c)
+	}
+
+	// `delete` is not reserved, so it is only considered to be a statement if it is followed by a group
+	iM, isM, err = matchWord(src, iM, c, matchOptions{Phrase: "("})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return opBreak(src, n, c)
+	}
+	n = iM
+
+	var paths [][]definition.JPLSelector
+	for {
+		var selectors []definition.JPLSelector
+		if n, selectors, err = parsePath(src, n, c, "delete statement"); err != nil {
+			return 0, nil, err
+		}
+		paths = append(paths, selectors)
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ")"})
+		if err != nil {
+			return 0, nil, err
+		}
+		if isM {
+			n = iM
+			break
+		}
+
+		iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ","})
+		if err != nil {
+			return 0, nil, err
+		}
+		n = iM
+		if !isM {
+			return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+				Operator: "delete statement",
+				Message:  "expected ',' or ')'",
+			})
+		}
+	}
+
+	return n, definition.Pipe{{OP: definition.OP_DELETE, Params: definition.JPLInstructionParams{Paths: paths}}}, nil
+}
+
+// Parse break statement at i
+func opBreak(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "break", SpaceAfter: true})
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isM {
+		return opConstant(src, n, c)
+	}
+
+	// `break` is not reserved, so it is only considered to be a statement if it is followed by a label name
+	iV, isV, name, _, err := safeVariable(src, iM, c)
+	if err != nil {
+		return 0, nil, err
+	}
+	if !isV {
+		return opConstant(src, n, c)
+	}
+	n = iV
+
+	return n, definition.Pipe{{OP: definition.OP_BREAK, Params: definition.JPLInstructionParams{Name: name}}}, nil
+}
+
 // Parse constant at i
 func opConstant(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
 	n = i
@@ -1498,7 +2615,8 @@ func opNamedFunctionDefinition(src string, i int, c *ParserContext) (n int, resu
 	n = iV
 
 	var argNames []string
-	if n, argNames, err = parseFunctionHeader(src, n, c); err != nil {
+	var args []definition.JPLArgument
+	if n, argNames, args, err = parseFunctionHeader(src, n, c); err != nil {
 		return 0, nil, err
 	}
 
@@ -1509,7 +2627,7 @@ func opNamedFunctionDefinition(src string, i int, c *ParserContext) (n int, resu
 
 	return n, definition.Pipe{{
 		OP:     definition.OP_VARIABLE_DEFINITION,
-		Params: definition.JPLInstructionParams{Name: name, Pipe: definition.Pipe{{OP: definition.OP_FUNCTION_DEFINITION, Params: definition.JPLInstructionParams{ArgNames: argNames, Pipe: ops}}}},
+		Params: definition.JPLInstructionParams{Name: name, Pipe: definition.Pipe{{OP: definition.OP_FUNCTION_DEFINITION, Params: definition.JPLInstructionParams{Name: name, ArgNames: argNames, Args: args, Pipe: ops}}}},
 	}}, nil
 }
 
@@ -1522,21 +2640,43 @@ func opFunctionDefinition(src string, i int, c *ParserContext) (n int, result de
 		return 0, nil, err
 	}
 	if !isM {
-		return opVariableAccess(src, n, c)
+		return opLambda(src, n, c)
 	}
 	n = iM
 
 	var argNames []string
-	if n, argNames, err = parseFunctionHeader(src, n, c); err != nil {
+	var args []definition.JPLArgument
+	if n, argNames, args, err = parseFunctionHeader(src, n, c); err != nil {
+		return 0, nil, err
+	}
+
+	var ops definition.Pipe
+	if n, ops, err = opSubRoute(src, n, c); err != nil {
+		return 0, nil, err
+	}
+
+	return n, definition.Pipe{{OP: definition.OP_FUNCTION_DEFINITION, Params: definition.JPLInstructionParams{ArgNames: argNames, Args: args, Pipe: ops}}}, nil
+}
+
+// Parse lambda at i, which is a shorthand for a function definition without arguments
+func opLambda(src string, i int, c *ParserContext) (n int, result definition.Pipe, err jpl.JPLSyntaxError) {
+	n = i
+
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "=>"})
+	if err != nil {
 		return 0, nil, err
 	}
+	if !isM {
+		return opVariableAccess(src, n, c)
+	}
+	n = iM
 
 	var ops definition.Pipe
 	if n, ops, err = opSubRoute(src, n, c); err != nil {
 		return 0, nil, err
 	}
 
-	return n, definition.Pipe{{OP: definition.OP_FUNCTION_DEFINITION, Params: definition.JPLInstructionParams{ArgNames: argNames, Pipe: ops}}}, nil
+	return n, definition.Pipe{{OP: definition.OP_FUNCTION_DEFINITION, Params: definition.JPLInstructionParams{Pipe: ops}}}, nil
 }
 
 // Parse variable definition at i
@@ -1548,6 +2688,14 @@ func opVariableAccess(src string, i int, c *ParserContext) (n int, result defini
 		return 0, nil, err
 	}
 	if !isV {
+		iD, isD, opsD, err := parseDestructuringDefinition(src, n, c)
+		if err != nil {
+			return 0, nil, err
+		}
+		if isD {
+			return iD, opsD, nil
+		}
+
 		return opValueAccess(src, n, c)
 	}
 	n = iV
@@ -1588,7 +2736,12 @@ func opVariableAccess(src string, i int, c *ParserContext) (n int, result defini
 	n = iAs
 
 	if len(selectors) == 0 && opAssignment.OP == definition.OPU_SET {
-		return n, definition.Pipe{{OP: definition.OP_VARIABLE_DEFINITION, Params: definition.JPLInstructionParams{Name: name, Pipe: opAssignment.Params.Pipe}}}, nil
+		pipe := opAssignment.Params.Pipe
+		// Functions that are directly assigned to a variable are named after it
+		if len(pipe) == 1 && pipe[0].OP == definition.OP_FUNCTION_DEFINITION && pipe[0].Params.Name == "" {
+			pipe[0].Params.Name = name
+		}
+		return n, definition.Pipe{{OP: definition.OP_VARIABLE_DEFINITION, Params: definition.JPLInstructionParams{Name: name, Pipe: pipe}}}, nil
 	}
 
 	return n, definition.Pipe{{
@@ -1614,11 +2767,20 @@ func opValueAccess(src string, i int, c *ParserContext) (n int, result definitio
 	var selectors []definition.JPLSelector
 
 	var ops definition.Pipe
-	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: "."})
+	iM, isM, err := matchWord(src, n, c, matchOptions{Phrase: ".", NotBeforeSet: "."})
 	if err != nil {
 		return 0, nil, err
 	}
+	var isD bool
 	if !isM {
+		// Recursive descents are parsed as part of the access
+		if _, isD, err = matchWord(src, n, c, matchOptions{Phrase: "..", NotBeforeSet: "."}); err != nil {
+			return 0, nil, err
+		}
+	}
+	if isD {
+		ops = nil
+	} else if !isM {
 		if n, ops, err = opObjectConstructor(src, n, c); err != nil {
 			return 0, nil, err
 		}
@@ -1705,6 +2867,44 @@ func opObjectConstructor(src string, i int, c *ParserContext) (n int, result def
 		n = iM
 	} else {
 		for {
+			iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "..."})
+			if err != nil {
+				return 0, nil, err
+			}
+			if isM {
+				n = iM
+
+				var opsValue definition.Pipe
+				if n, opsValue, err = opSubPipe(src, n, c); err != nil {
+					return 0, nil, err
+				}
+
+				fields = append(fields, definition.JPLField{Value: opsValue, Spread: true})
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "}"})
+				if err != nil {
+					return 0, nil, err
+				}
+				if isM {
+					n = iM
+					break
+				}
+
+				iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: ","})
+				if err != nil {
+					return 0, nil, err
+				}
+				n = iM
+				if !isM {
+					return 0, nil, errorUnexpectedToken(src, n, c, errorOptions{
+						Operator: "object",
+						Message:  "expected ',' or '}'",
+					})
+				}
+
+				continue
+			}
+
 			iM, isM, err = matchWord(src, n, c, matchOptions{Phrase: "("})
 			if err != nil {
 				return 0, nil, err
@@ -1919,7 +3119,9 @@ func opArrayConstructor(src string, i int, c *ParserContext) (n int, result defi
 
 		ops = definition.Pipe{{OP: definition.OP_VOID}}
 	} else {
-		if n, ops, err = opPipe(src, n, c); err != nil {
+		itemsContext := *c
+		itemsContext.spread = true
+		if n, ops, err = opPipe(src, n, &itemsContext); err != nil {
 			return 0, nil, err
 		}
 
@@ -1945,7 +3147,14 @@ func opStringLiteral(src string, i int, c *ParserContext) (n int, result definit
 		return 0, nil, err
 	}
 	if !isS {
-		return opGroup(src, n, c)
+		iR, isR, opsR, err := parseRegex(src, n, c)
+		if err != nil {
+			return 0, nil, err
+		}
+		if !isR {
+			return opGroup(src, n, c)
+		}
+		return iR, opsR, nil
 	}
 	n = iS
 
@@ -3,6 +3,7 @@ package interpreter
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/jplorg/jpl/go/jpl"
@@ -16,6 +17,7 @@ const setVarRest = setVarFirst + setDigit
 const setVarAll = setVarRest
 const setWhitespace = " \r\n\t"
 const setHex = setDigit + "abcdefABCDEF"
+const setStringBoundary = "\"'`"
 
 // Walk whitespace at i
 func walkWhitespace(src string, i int, c *ParserContext) (n int, is bool, err jpl.JPLSyntaxError) {
@@ -182,6 +184,13 @@ func variable(src string, i int, c *ParserContext) (n int, is bool, value string
 		value += valueSet
 	}
 
+	// `r` directly followed by a string boundary introduces a raw string
+	if value == "r" {
+		if _, isSet, _ := matchSet(src, n, c, matchSetOptions{Set: setStringBoundary}); isSet {
+			return i, false, "", nil
+		}
+	}
+
 	if is {
 		if n, _, err = walkWhitespace(src, n, c); err != nil {
 			return 0, false, "", err
@@ -204,6 +213,9 @@ func safeVariable(src string, i int, c *ParserContext) (n int, is bool, value st
 		return i, false, value, true, nil
 
 	default:
+		if slices.Contains(c.reserved, value) {
+			return i, false, value, true, nil
+		}
 		return
 	}
 }
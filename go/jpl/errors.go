@@ -62,3 +62,9 @@ type JPLTypeConversionError interface {
 	JPLRuntimeError
 	IsJPLTypeConversionError()
 }
+
+// JPL runtime error type for errors thrown by the program itself, e.g. using the `error` builtin
+type JPLUserError interface {
+	JPLRuntimeError
+	IsJPLUserError()
+}
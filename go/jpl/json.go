@@ -0,0 +1,34 @@
+package jpl
+
+// Options for stringifying values as JSON.
+// Options that are nil have not been specified, so that an explicit value can override a default value.
+type JPLJSONOptions struct {
+	// Indentation for each level of nesting, or empty for compact output
+	Indent *string
+
+	// Whether object keys are sorted by their unicode code points
+	SortKeys *bool
+
+	// Whether all non-ASCII characters are escaped
+	ASCIIOnly *bool
+
+	// Whether canonical JSON (RFC 8785) is produced, which overrides all other options
+	Canonical *bool
+}
+
+// Apply the specified defaults to all options that have not been specified
+func ApplyJSONDefaults(options JPLJSONOptions, defaults JPLJSONOptions) (result JPLJSONOptions) {
+	result.Indent = applyOptionDefault(options.Indent, defaults.Indent)
+	result.SortKeys = applyOptionDefault(options.SortKeys, defaults.SortKeys)
+	result.ASCIIOnly = applyOptionDefault(options.ASCIIOnly, defaults.ASCIIOnly)
+	result.Canonical = applyOptionDefault(options.Canonical, defaults.Canonical)
+
+	return
+}
+
+func applyOptionDefault[T any](option *T, defaultOption *T) *T {
+	if option != nil {
+		return option
+	}
+	return defaultOption
+}
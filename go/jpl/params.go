@@ -5,15 +5,21 @@ import "github.com/jplorg/jpl/go/definition"
 type JPLInstructionParams struct {
 	After          string
 	ArgNames       []string
+	Args           []JPLArgument
 	Assignment     *JPLAssignment
+	Cases          []JPLMatchCase
 	Catch          JPLFunc
+	Catches        []JPLCatch
 	Comparisons    []JPLComparison
 	Else           JPLFunc
 	Fields         []JPLField
+	Flags          string
 	Ifs            []JPLIfThen
 	Interpolations []JPLInterpolation
 	Name           string
 	Operations     []JPLOperation
+	Paths          [][]JPLSelector
+	Pattern        *JPLPattern
 	Pipe           JPLFunc
 	Pipes          []JPLFunc
 	Selectors      []JPLSelector
@@ -73,6 +79,48 @@ type JPLField struct {
 	Key      JPLFunc
 	Value    JPLFunc
 	Optional bool
+	Spread   bool
+}
+
+type JPLPattern struct {
+	OP     definition.JPLOPD
+	Params JPLPatternParams
+}
+
+type JPLPatternParams struct {
+	Entries []JPLPatternEntry
+	Name    string
+	Pattern *JPLPattern
+	Pipe    JPLFunc
+	Rest    string
+	Type    string
+}
+
+type JPLPatternEntry struct {
+	Key      JPLFunc
+	Pattern  JPLPattern
+	Default  JPLFunc
+	Optional bool
+}
+
+type JPLMatchCase struct {
+	Pattern JPLPattern
+	If      JPLFunc
+	Then    JPLFunc
+}
+
+type JPLCatch struct {
+	Kinds []string
+	When  JPLFunc
+	Raw   bool
+	Pipe  JPLFunc
+}
+
+type JPLArgument struct {
+	Name     string
+	Types    []string
+	Optional bool
+	Default  JPLFunc
 }
 
 type JPLInterpolation struct {
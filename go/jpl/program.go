@@ -9,9 +9,14 @@ type JPLProgramConfig struct {
 	Runtime JPLRuntimeOptions
 }
 
-type JPLProgramOptions struct{}
+type JPLProgramOptions struct {
+	// Options for stringifying program outputs in `library.RunJSON`
+	JSON JPLJSONOptions
+}
 
 func ApplyProgramDefaults(options JPLProgramOptions, defaults JPLProgramOptions) (result JPLProgramOptions) {
+	result.JSON = ApplyJSONDefaults(options.JSON, defaults.JSON)
+
 	return
 }
 
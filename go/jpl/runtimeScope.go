@@ -3,6 +3,7 @@ package jpl
 type JPLRuntimeScopeConfig struct {
 	Signal JPLRuntimeSignal
 	Vars   map[string]any
+	Labels map[string]JPLRuntimeSignal
 }
 
 type JPLRuntimeScope interface {
@@ -15,12 +15,14 @@ func NewRuntimeScope(presets *jpl.JPLRuntimeScopeConfig) jpl.JPLRuntimeScope {
 	return &runtimeScope{
 		signal: signal,
 		vars:   presets.Vars,
+		labels: presets.Labels,
 	}
 }
 
 type runtimeScope struct {
 	signal jpl.JPLRuntimeSignal
 	vars   map[string]any
+	labels map[string]jpl.JPLRuntimeSignal
 }
 
 func (s *runtimeScope) Signal() jpl.JPLRuntimeSignal {
@@ -31,6 +33,11 @@ func (s *runtimeScope) Vars() map[string]any {
 	return s.vars
 }
 
+// Return the runtime areas of all labels that can be broken out of
+func (s *runtimeScope) Labels() map[string]jpl.JPLRuntimeSignal {
+	return s.labels
+}
+
 func (s *runtimeScope) Next(modifications *jpl.JPLRuntimeScopeConfig) jpl.JPLRuntimeScope {
 	if modifications == nil {
 		modifications = new(jpl.JPLRuntimeScopeConfig)
@@ -44,5 +51,6 @@ func (s *runtimeScope) Next(modifications *jpl.JPLRuntimeScopeConfig) jpl.JPLRun
 	return &runtimeScope{
 		signal: signal,
 		vars:   ApplyObject(s.vars, ObjectEntries(modifications.Vars)),
+		labels: ApplyObject(s.labels, ObjectEntries(modifications.Labels)),
 	}
 }
@@ -0,0 +1,86 @@
+package library
+
+import (
+	"strings"
+
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+const ERROR_KIND_ERROR = "Error"
+const ERROR_KIND_RUNTIME_ERROR = "RuntimeError"
+const ERROR_KIND_TYPE_ERROR = "TypeError"
+const ERROR_KIND_REFERENCE_ERROR = "ReferenceError"
+const ERROR_KIND_ZERO_DIVISION_ERROR = "ZeroDivisionError"
+const ERROR_KIND_TYPE_CONVERSION_ERROR = "TypeConversionError"
+const ERROR_KIND_EXECUTION_ERROR = "ExecutionError"
+
+// Resolve the kind of the specified execution error
+func ErrorKind(err jpl.JPLExecutionError) string {
+	switch err.(type) {
+	case jpl.JPLUserError:
+		return ERROR_KIND_ERROR
+	case jpl.JPLTypeError:
+		return ERROR_KIND_TYPE_ERROR
+	case jpl.JPLReferenceError:
+		return ERROR_KIND_REFERENCE_ERROR
+	case jpl.JPLZeroDivisionError:
+		return ERROR_KIND_ZERO_DIVISION_ERROR
+	case jpl.JPLTypeConversionError:
+		return ERROR_KIND_TYPE_CONVERSION_ERROR
+	case jpl.JPLRuntimeError:
+		return ERROR_KIND_RUNTIME_ERROR
+	default:
+		return ERROR_KIND_EXECUTION_ERROR
+	}
+}
+
+// Create the structured error value `{ kind, message, value }` for the specified execution error.
+// The message does not include the kind prefix of runtime errors.
+func ErrorInfo(err jpl.JPLExecutionError) map[string]any {
+	kind := ErrorKind(err)
+	message := err.JPLErrorMessage()
+	switch kind {
+	case ERROR_KIND_TYPE_ERROR, ERROR_KIND_REFERENCE_ERROR, ERROR_KIND_ZERO_DIVISION_ERROR, ERROR_KIND_TYPE_CONVERSION_ERROR:
+		message = strings.TrimPrefix(message, kind+" - ")
+	}
+	return map[string]any{
+		"kind":    kind,
+		"message": message,
+		"value":   err.JPLErrorValue(),
+	}
+}
+
+// Recreate the execution error described by the specified structured error value, which must have been created using `ErrorInfo`.
+// It returns nil if the value does not describe an error.
+func ErrorFromInfo(info any) (jpl.JPLError, jpl.JPLError) {
+	v, err := UnwrapValue(info)
+	if err != nil {
+		return nil, err
+	}
+	fields, ok := v.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	kind, _ := fields["kind"].(string)
+	message, _ := fields["message"].(string)
+	value := fields["value"]
+
+	switch kind {
+	case ERROR_KIND_ERROR:
+		return NewUserError(value)
+	case ERROR_KIND_RUNTIME_ERROR:
+		return NewRuntimeError(value)
+	case ERROR_KIND_TYPE_ERROR:
+		return NewTypeError("%s", message)
+	case ERROR_KIND_REFERENCE_ERROR:
+		return NewReferenceError("%s", message)
+	case ERROR_KIND_ZERO_DIVISION_ERROR:
+		return NewZeroDivisionError("%s", message)
+	case ERROR_KIND_TYPE_CONVERSION_ERROR:
+		return NewTypeConversionError("%s", message)
+	case ERROR_KIND_EXECUTION_ERROR:
+		return NewExecutionError(message, ""), nil
+	default:
+		return nil, nil
+	}
+}
@@ -122,3 +122,17 @@ func NewTypeConversionError(value any, replacements ...any) (jpl.JPLTypeConversi
 type typeConversionError struct{ jpl.JPLRuntimeError }
 
 func (typeConversionError) IsJPLTypeConversionError() {}
+
+// `value` can by of any type.
+// User errors are thrown by the program itself, e.g. using the `error` builtin.
+func NewUserError(value any) (jpl.JPLUserError, jpl.JPLError) {
+	runtimeErr, err := NewRuntimeError(value)
+	if err != nil {
+		return nil, err
+	}
+	return userError{runtimeErr}, nil
+}
+
+type userError struct{ jpl.JPLRuntimeError }
+
+func (userError) IsJPLUserError() {}
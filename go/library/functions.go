@@ -1,17 +1,27 @@
 package library
 
 import (
+	"maps"
+	"slices"
+	"strings"
+
 	"github.com/jplorg/jpl/go/definition"
 	"github.com/jplorg/jpl/go/jpl"
 )
 
 type jplEnclosure struct {
+	name     string
 	argNames []string
+	args     []definition.JPLArgument
 	pipe     definition.Pipe
 	scope    jpl.JPLRuntimeScope
 }
 
 func (e *jplEnclosure) Call(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args ...any) ([]any, error) {
+	if e.args != nil {
+		return e.callTyped(runtime, signal, next, input, args)
+	}
+
 	argCount := len(args)
 	vars := make(map[string]any, len(e.argNames))
 	for i, name := range e.argNames {
@@ -32,6 +42,89 @@ func (e *jplEnclosure) Call(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal,
 	)
 }
 
+func (e *jplEnclosure) callTyped(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, next jpl.JPLPiper, input any, args []any) ([]any, jpl.JPLError) {
+	if len(args) > len(e.args) {
+		return nil, ThrowAny(NewTypeError("too many arguments for %s: expected at most %v, got %v", e.label(), float64(len(e.args)), float64(len(args))))
+	}
+
+	return e.bindArgs(runtime, signal, input, args, 0, make(map[string]any, len(e.args)), func(vars map[string]any) ([]any, jpl.JPLError) {
+		return runtime.ExecuteInstructions(
+			e.pipe,
+			[]any{input},
+			e.scope.Next(&jpl.JPLRuntimeScopeConfig{
+				Signal: signal,
+				Vars:   vars,
+			}),
+			NewPiperWithScope(next),
+		)
+	})
+}
+
+// Bind the argument at i and all of its successors to vars, validating their types.
+// Omitted arguments are replaced by their default values, which have access to the preceding arguments.
+func (e *jplEnclosure) bindArgs(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, input any, args []any, i int, vars map[string]any, next func(vars map[string]any) ([]any, jpl.JPLError)) ([]any, jpl.JPLError) {
+	if i >= len(e.args) {
+		return next(vars)
+	}
+	arg := e.args[i]
+
+	bind := func(value any) ([]any, jpl.JPLError) {
+		nextVars := maps.Clone(vars)
+		nextVars[arg.Name] = value
+		return e.bindArgs(runtime, signal, input, args, i+1, nextVars, next)
+	}
+
+	if i < len(args) {
+		if err := e.assertArgType(arg, args[i]); err != nil {
+			return nil, err
+		}
+		return bind(args[i])
+	}
+
+	if arg.Default != nil {
+		return runtime.ExecuteInstructions(arg.Default, []any{input}, e.scope.Next(&jpl.JPLRuntimeScopeConfig{Signal: signal, Vars: vars}), jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+			if err := e.assertArgType(arg, output); err != nil {
+				return nil, err
+			}
+			return bind(output)
+		}))
+	}
+
+	if !arg.Optional {
+		return nil, ThrowAny(NewTypeError("%s is missing argument %s", e.label(), arg.Name))
+	}
+	return bind(nil)
+}
+
+// Assert that the specified value matches one of the types of the argument, if any
+func (e *jplEnclosure) assertArgType(arg definition.JPLArgument, value any) jpl.JPLError {
+	if len(arg.Types) == 0 {
+		return nil
+	}
+
+	v, err := UnwrapValue(value)
+	if err != nil {
+		return err
+	}
+	t, err := Type(v)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(arg.Types, string(t)) {
+		return nil
+	}
+
+	return ThrowAny(NewTypeError("%s expects argument %s to be %s but got %s (%*<100v)", e.label(), arg.Name, strings.Join(arg.Types, " | "), string(t), v))
+}
+
+// Describe the function for error messages
+func (e *jplEnclosure) label() string {
+	if e.name == "" {
+		return "anonymous function"
+	}
+	return "function " + e.name
+}
+
 // Create a scoped JPL function from the specified instructions.
 //
 // The function is bound to the specified scope.
@@ -39,6 +132,15 @@ func ScopedFunction(argNames []string, instructions definition.Pipe, scope jpl.J
 	return (&jplEnclosure{argNames: argNames, pipe: instructions, scope: scope}).Call
 }
 
+// Create a scoped JPL function with a typed signature from the specified instructions.
+//
+// The number of arguments and their types are validated when the function is called.
+// Omitted arguments are replaced by their default values, if any.
+// The name is only used for describing the function in error messages and may be empty.
+func TypedFunction(name string, args []definition.JPLArgument, instructions definition.Pipe, scope jpl.JPLRuntimeScope) jpl.JPLFunc {
+	return (&jplEnclosure{name: name, args: args, pipe: instructions, scope: scope}).Call
+}
+
 // Create an orphan JPL function from the specified instructions.
 //
 // Some optional scope presets may be specified, e.g. for allowing the function access to some specified variables.
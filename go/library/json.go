@@ -0,0 +1,193 @@
+package library
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+const hexDigits = "0123456789abcdef"
+
+// Stringify the specified normalized value as JSON for usage in program outputs, using the specified options
+func FormatJSON(value any, options jpl.JPLJSONOptions) (string, jpl.JPLError) {
+	stripped, err := StripJSON(value)
+	if err != nil {
+		return "", err
+	}
+	var format jsonFormat
+	if options.Canonical != nil && *options.Canonical {
+		format.canonical = true
+	} else {
+		if options.Indent != nil {
+			format.indent = *options.Indent
+		}
+		format.asciiOnly = options.ASCIIOnly != nil && *options.ASCIIOnly
+	}
+	var b strings.Builder
+	writeJSON(&b, stripped, format, "")
+	return b.String(), nil
+}
+
+// JSON options for stringifying values, where all unspecified options have been resolved
+type jsonFormat struct {
+	indent    string
+	asciiOnly bool
+	canonical bool
+}
+
+// Run the specified program like `JPLProgram.Run` and stringify its outputs as JSON using the program's JSON options,
+// which may be overridden by the provided program options.
+func RunJSON(program jpl.JPLProgram, inputs []any, options *jpl.JPLProgramConfig) ([]string, jpl.JPLError) {
+	if options == nil {
+		options = new(jpl.JPLProgramConfig)
+	}
+
+	outputs, err := program.Run(inputs, options)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonOptions := jpl.ApplyProgramDefaults(options.Program, program.Options()).JSON
+	results := make([]string, len(outputs))
+	for i, output := range outputs {
+		if results[i], err = FormatJSON(output, jsonOptions); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func writeJSON(b *strings.Builder, value any, options jsonFormat, indent string) {
+	switch v := value.(type) {
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+
+	case float64:
+		writeJSONNumber(b, v)
+
+	case string:
+		writeJSONString(b, v, options.asciiOnly)
+
+	case []any:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		inner := indent + options.indent
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeJSONIndent(b, options, inner)
+			writeJSON(b, item, options, inner)
+		}
+		writeJSONIndent(b, options, indent)
+		b.WriteByte(']')
+
+	case map[string]any:
+		if len(v) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		if options.canonical {
+			// RFC 8785 sorts keys by their UTF-16 code units
+			slices.SortFunc(keys, func(a, b string) int {
+				return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+			})
+		} else {
+			// Go maps are unordered, so keys are always sorted by their unicode code points
+			slices.Sort(keys)
+		}
+		inner := indent + options.indent
+		b.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeJSONIndent(b, options, inner)
+			writeJSONString(b, key, options.asciiOnly)
+			b.WriteByte(':')
+			if options.indent != "" {
+				b.WriteByte(' ')
+			}
+			writeJSON(b, v[key], options, inner)
+		}
+		writeJSONIndent(b, options, indent)
+		b.WriteByte('}')
+
+	default:
+		b.WriteString("null")
+	}
+}
+
+func writeJSONIndent(b *strings.Builder, options jsonFormat, indent string) {
+	if options.indent != "" {
+		b.WriteByte('\n')
+		b.WriteString(indent)
+	}
+}
+
+// Write the specified number like JavaScript's `Number.prototype.toString`, which is also required by RFC 8785
+func writeJSONNumber(b *strings.Builder, n float64) {
+	if math.IsInf(n, 0) || math.IsNaN(n) {
+		b.WriteString("null")
+		return
+	}
+	abs := math.Abs(n)
+	format := byte('f')
+	if abs != 0 && (abs < 1e-6 || abs >= 1e21) {
+		format = 'e'
+	}
+	// Adding zero normalizes negative zero
+	s := strconv.FormatFloat(n+0, format, -1, 64)
+	if format == 'e' {
+		// Clean up exponents like e-07 to e-7
+		if i := strings.Index(s, "e-0"); i >= 0 {
+			s = s[:i+2] + s[i+3:]
+		}
+	}
+	b.WriteString(s)
+}
+
+func writeJSONString(b *strings.Builder, s string, asciiOnly bool) {
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || (asciiOnly && r > 0x7f) {
+				for _, unit := range utf16.Encode([]rune{r}) {
+					b.WriteString(`\u`)
+					for shift := 12; shift >= 0; shift -= 4 {
+						b.WriteByte(hexDigits[unit>>shift&0xf])
+					}
+				}
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+}
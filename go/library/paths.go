@@ -0,0 +1,221 @@
+package library
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+var identityFunction = NativeFunction(func(runtime jpl.JPLRuntime, input any, args ...any) ([]any, error) {
+	return []any{input}, nil
+})
+
+func constantFunction(value any) jpl.JPLFunc {
+	return NativeFunction(func(runtime jpl.JPLRuntime, input any, args ...any) ([]any, error) {
+		return []any{value}, nil
+	})
+}
+
+var collectPiper = NewPiperWithScope(jpl.JPLPiperFunc(func(output any) ([]any, jpl.JPLError) {
+	return []any{output}, nil
+}))
+
+// Create selectors for the specified path, which behave like the corresponding literal path.
+//
+// Strings and numbers select fields, while objects of the form `{ from, to }` select slices.
+func pathSelectors(path []any) []jpl.JPLSelector {
+	selectors := make([]jpl.JPLSelector, len(path))
+	for i, key := range path {
+		if slice, ok := key.(map[string]any); ok {
+			selectors[i] = jpl.JPLSelector{OP: definition.OPA_SLICE, Params: jpl.JPLSelectorParams{From: constantFunction(slice["from"]), To: constantFunction(slice["to"])}}
+		} else {
+			selectors[i] = jpl.JPLSelector{OP: definition.OPA_FIELD, Params: jpl.JPLSelectorParams{Pipe: constantFunction(key)}}
+		}
+	}
+	return selectors
+}
+
+// Resolve the value at the specified path of unwrapped keys
+func GetPath(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, value any, path []any) (any, jpl.JPLError) {
+	results, err := runtime.OP(
+		definition.OP_ACCESS,
+		jpl.JPLInstructionParams{Pipe: identityFunction, Selectors: pathSelectors(path)},
+		[]any{value},
+		runtime.CreateScope(&jpl.JPLRuntimeScopeConfig{Signal: signal}),
+		collectPiper,
+	)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Replace the value at the specified path of unwrapped keys
+func SetPath(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, value any, path []any, replacement any) (any, jpl.JPLError) {
+	results, err := runtime.OP(
+		definition.OP_ASSIGNMENT,
+		jpl.JPLInstructionParams{
+			Pipe:       identityFunction,
+			Selectors:  pathSelectors(path),
+			Assignment: &jpl.JPLAssignment{OP: definition.OPU_SET, Params: jpl.JPLAssignmentParams{Pipe: constantFunction(replacement)}},
+		},
+		[]any{value},
+		runtime.CreateScope(&jpl.JPLRuntimeScopeConfig{Signal: signal}),
+		collectPiper,
+	)
+	if err != nil || len(results) == 0 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// Remove the values at all specified paths of unwrapped keys.
+// Paths that do not exist are ignored.
+//
+// All paths refer to the original value, so removing array items does not affect the indices of the remaining paths.
+func DeletePaths(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, value any, paths [][]any) (any, jpl.JPLError) {
+	// Negative indices are resolved against the original value first, so that they are sorted by their actual position
+	sorted := make([][]any, len(paths))
+	for i, path := range paths {
+		var err jpl.JPLError
+		if sorted[i], err = resolvePath(value, path); err != nil {
+			return nil, err
+		}
+	}
+
+	// Delete paths in descending order, so that removing array items does not shift the indices of the remaining paths
+	var sortErr jpl.JPLError
+	slices.SortFunc(sorted, func(a, b []any) int {
+		c, err := CompareArrays(b, a)
+		if err != nil {
+			if sortErr == nil {
+				sortErr = err
+			}
+			return 0
+		}
+		return c
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+
+	result := value
+	for i, path := range sorted {
+		if i > 0 {
+			if same, err := Equals(path, sorted[i-1]); err != nil {
+				return nil, err
+			} else if same {
+				continue
+			}
+		}
+		var err jpl.JPLError
+		if result, err = deletePath(runtime, signal, result, path); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Resolve all negative array indices of the specified path against the specified value
+func resolvePath(value any, path []any) ([]any, jpl.JPLError) {
+	resolved := CopySlice(path)
+	current := value
+	for i, key := range path {
+		v, err := UnwrapValue(current)
+		if err != nil {
+			return nil, err
+		}
+		current = nil
+		switch t := v.(type) {
+		case map[string]any:
+			if k, ok := key.(string); ok {
+				current = t[k]
+			}
+
+		case []any:
+			if k, ok := key.(float64); ok {
+				j := int(k)
+				if j < 0 && len(t)+j >= 0 {
+					j = len(t) + j
+					resolved[i] = float64(j)
+				}
+				if j >= 0 && j < len(t) {
+					current = t[j]
+				}
+			}
+
+		default:
+		}
+	}
+	return resolved, nil
+}
+
+func deletePath(runtime jpl.JPLRuntime, signal jpl.JPLRuntimeSignal, value any, path []any) (any, jpl.JPLError) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+
+	parentPath := path[:len(path)-1]
+	parent, err := GetPath(runtime, signal, value, parentPath)
+	if err != nil {
+		return nil, err
+	}
+	p, err := UnwrapValue(parent)
+	if err != nil {
+		return nil, err
+	}
+	tp, err := Type(p)
+	if err != nil {
+		return nil, err
+	}
+	key := path[len(path)-1]
+	tk, err := Type(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tp {
+	case jpl.JPLT_NULL:
+		if tk == jpl.JPLT_STRING || tk == jpl.JPLT_NUMBER {
+			return value, nil
+		}
+
+	case jpl.JPLT_OBJECT:
+		if tk == jpl.JPLT_STRING {
+			if _, ok := p.(map[string]any)[key.(string)]; !ok {
+				return value, nil
+			}
+			altered, err := AlterValue(parent, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+				return ApplyObject(value.(map[string]any), []*ObjectEntry[any]{{Key: key.(string), NoValue: true}}), nil
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return SetPath(runtime, signal, value, parentPath, altered)
+		}
+
+	case jpl.JPLT_ARRAY:
+		if tk == jpl.JPLT_NUMBER {
+			l := len(p.([]any))
+			i := int(key.(float64))
+			if i < 0 {
+				i = l + i
+			}
+			if i < 0 || i >= l {
+				return value, nil
+			}
+			altered, err := AlterValue(parent, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) {
+				return slices.Delete(CopySlice(value.([]any)), i, i+1), nil
+			}))
+			if err != nil {
+				return nil, err
+			}
+			return SetPath(runtime, signal, value, parentPath, altered)
+		}
+
+	default:
+	}
+
+	return nil, ThrowAny(NewTypeError("cannot delete field of %s (%*<100v) with %s (%*<100v)", string(tp), p, string(tk), key))
+}
@@ -0,0 +1,222 @@
+package library
+
+import (
+	"container/list"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+// Flags that are supported by regular expressions.
+//
+// - `g`: Match all occurrences instead of only the first one
+// - `i`: Match letters case insensitively
+// - `m`: Let `^` and `$` match at the start and end of lines
+// - `s`: Let `.` match newlines
+const RegexFlags = "gims"
+
+// Compiled regular expression, which is represented as `{ pattern, flags }` in JPL
+type Regex struct {
+	Pattern string
+	Flags   string
+	Global  bool
+	Regexp  *regexp.Regexp
+}
+
+// Maximum number of compiled regular expressions that are kept for reuse
+const regexCacheSize = 64
+
+// Cache of the most recently used compiled regular expressions
+var regexCache = struct {
+	sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}{entries: map[string]*list.Element{}, order: list.New()}
+
+type regexCacheEntry struct {
+	key   string
+	regex *Regex
+}
+
+// Compile the specified regular expression.
+// Only the most recently used expressions are cached, so that dynamic patterns cannot grow the cache indefinitely.
+func CompileRegex(pattern string, flags string) (*Regex, error) {
+	key := flags + "/" + pattern
+	regexCache.Lock()
+	if element, ok := regexCache.entries[key]; ok {
+		regexCache.order.MoveToFront(element)
+		regexCache.Unlock()
+		return element.Value.(*regexCacheEntry).regex, nil
+	}
+	regexCache.Unlock()
+
+	regex, err := compileRegex(pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Lock()
+	defer regexCache.Unlock()
+	if _, ok := regexCache.entries[key]; !ok {
+		regexCache.entries[key] = regexCache.order.PushFront(&regexCacheEntry{key: key, regex: regex})
+		if regexCache.order.Len() > regexCacheSize {
+			oldest := regexCache.order.Remove(regexCache.order.Back()).(*regexCacheEntry)
+			delete(regexCache.entries, oldest.key)
+		}
+	}
+	return regex, nil
+}
+
+func compileRegex(pattern string, flags string) (*Regex, error) {
+	var global bool
+	var inline string
+	for i, flag := range flags {
+		if !strings.ContainsRune(RegexFlags, flag) {
+			return nil, fmt.Errorf("invalid regular expression /%s/%s: unknown flag %c", pattern, flags, flag)
+		}
+		if strings.ContainsRune(flags[:i], flag) {
+			return nil, fmt.Errorf("invalid regular expression /%s/%s: duplicate flag %c", pattern, flags, flag)
+		}
+		if flag == 'g' {
+			global = true
+		} else {
+			inline += string(flag)
+		}
+	}
+
+	source := pattern
+	if inline != "" {
+		source = "(?" + inline + ")" + pattern
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		message := err.Error()
+		if e, ok := err.(*syntax.Error); ok {
+			message = string(e.Code)
+		}
+		return nil, fmt.Errorf("invalid regular expression /%s/%s: %s", pattern, flags, message)
+	}
+
+	return &Regex{Pattern: pattern, Flags: flags, Global: global, Regexp: re}, nil
+}
+
+// Create a new JPLType for the specified regular expression
+func NewRegex(pattern string, flags string) (jpl.JPLType, error) {
+	regex, err := CompileRegex(pattern, flags)
+	if err != nil {
+		return nil, err
+	}
+	return &regexType{regex: regex, value: map[string]any{"pattern": pattern, "flags": flags}}, nil
+}
+
+type regexType struct {
+	regex *Regex
+	value map[string]any
+}
+
+func (t *regexType) Value() (any, jpl.JPLError) {
+	return t.value, nil
+}
+
+func (t *regexType) JSON() (any, jpl.JPLError) {
+	return t.value, nil
+}
+
+func (t *regexType) Alter(updater jpl.JPLModifier) (any, jpl.JPLError) {
+	return AlterJPLType(t, updater)
+}
+
+func (t *regexType) IsSame(other jpl.JPLType) bool {
+	return t == other
+}
+
+func (t *regexType) MarshalJSON() ([]byte, error) {
+	return MarshalJPLType(t)
+}
+
+// Resolve the regular expression for the specified normalized value.
+// Besides regular expression literals, objects in the form `{ pattern, flags }` are accepted as well.
+//
+// If the value cannot be used as a regular expression, nil is returned.
+func ResolveRegex(value any) (*Regex, jpl.JPLError) {
+	if t, ok := value.(*regexType); ok {
+		return t.regex, nil
+	}
+
+	v, err := UnwrapValue(value)
+	if err != nil {
+		return nil, err
+	}
+	o, ok := v.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	pattern, ok := o["pattern"].(string)
+	if !ok {
+		return nil, nil
+	}
+	var flags string
+	switch f := o["flags"].(type) {
+	case string:
+		flags = f
+
+	case nil:
+
+	default:
+		return nil, nil
+	}
+
+	regex, err2 := CompileRegex(pattern, flags)
+	if err2 != nil {
+		return nil, ThrowAny(NewRuntimeError(err2.Error()))
+	}
+	return regex, nil
+}
+
+// Find the matches of the regular expression in the specified string.
+// Unless all is set, at most one match is returned.
+//
+// Each match is represented as `{ offset, length, string, captures: [{ offset, length, string, name }] }`, where offsets and lengths are specified in unicode codepoints.
+// Captures that did not participate in the match have an offset of -1 and a string of null.
+func (r *Regex) FindMatches(value string, all bool) []any {
+	n := 1
+	if all {
+		n = -1
+	}
+
+	names := r.Regexp.SubexpNames()
+	var matches []any
+	for _, loc := range r.Regexp.FindAllStringSubmatchIndex(value, n) {
+		captures := make([]any, 0, len(names)-1)
+		for i := 1; i < len(names); i += 1 {
+			var name any
+			if names[i] != "" {
+				name = names[i]
+			}
+			capture := map[string]any{"offset": float64(-1), "length": float64(0), "string": nil, "name": name}
+			if loc[2*i] >= 0 {
+				capture = regexMatch(value, loc[2*i], loc[2*i+1])
+				capture["name"] = name
+			}
+			captures = append(captures, capture)
+		}
+
+		match := regexMatch(value, loc[0], loc[1])
+		match["captures"] = captures
+		matches = append(matches, match)
+	}
+	return matches
+}
+
+func regexMatch(value string, start int, end int) map[string]any {
+	return map[string]any{
+		"offset": float64(utf8.RuneCountInString(value[:start])),
+		"length": float64(utf8.RuneCountInString(value[start:end])),
+		"string": value[start:end],
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
@@ -178,30 +179,54 @@ func Stringify(value any, unescapeString bool, escapeFunctions bool) (string, jp
 	}
 }
 
-var placeholder = regexp.MustCompile(`%(?P<Flags>[*\-<]+)?(?P<Width>[1-9][0-9]*)?(?P<Verb>.)`)
+var placeholder = regexp.MustCompile(`%(?P<Flags>[*\-<0+]+)?(?P<Width>[1-9][0-9]*)?(?:\.(?P<Precision>[0-9]+))?(?P<Verb>.)`)
 
 // Format the specified template string.
-// The general form of a format is a percent sign, followed by optional flags, an optional width and a verb.
+// The general form of a format is a percent sign, followed by optional flags, an optional width, an optional precision and a verb.
 //
 // Examples:
 // - `%10s`: Format the next replacement as a string and pads the result at the left with spaces to be at least 10 unicode codepoints long.
 // - `%*<10v`: Format the next replacement as a JSON value and truncates it, if it is longer then 10 unicode codepoints.
+// - `%08.2f`: Format the next replacement as a number with two decimals and pads the result at the left with zeros to be at least 8 unicode codepoints long.
 //
 // The width specifies the desired field width and defaults to whatever is necessary to display the full replacement.
 // If the width is specified without any other corresponding flags, it is used for padding the field if necessary.
 //
+// The precision is introduced by a period and must not exceed 100.
+// For the verbs `s` and `v`, it specifies the maximum number of unicode codepoints, which the replacement is cut off at.
+// For the verbs `f` and `e`, it specifies the number of decimals and defaults to 6.
+//
 // Valid flags:
 //
 // - `*`: Do not pad the value even if it is shorter than the specified width
 // - `-`: Pad the value at the right rather than the left
 // - `<`: Truncate the value at the right if it is too long for the specified width
+// - `0`: Pad numbers with leading zeros after the sign rather than with spaces
+// - `+`: Always print a sign for numbers
 //
 // Valid verbs:
 //
 // - `%`: Returns a literal `%`
 // - `s`: Format the next replacement as a string (like JSON, but does not escape strings)
 // - `v`: Format the next replacement as a JSON value
+// - `d`: Format the next replacement, which must be a number, as an integer, rounding half away from zero
+// - `f`: Format the next replacement, which must be a number, in fixed-point notation, rounding half up
+// - `e`: Format the next replacement, which must be a number, in exponential notation, rounding half up
 func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
+	return template(func(message string) jpl.JPLError {
+		return NewFatalError(message)
+	}, tmpl, replacements)
+}
+
+// Format the specified template string like `Template`, but report invalid templates and replacements as runtime errors.
+// This allows formatting templates that are provided by JPL programs.
+func FormatTemplate(tmpl any, replacements ...any) (string, jpl.JPLError) {
+	return template(func(message string) jpl.JPLError {
+		return ThrowAny(NewRuntimeError(message))
+	}, tmpl, replacements)
+}
+
+func template(fail func(message string) jpl.JPLError, tmpl any, replacements []any) (string, jpl.JPLError) {
 	var i int
 	v, err := DisplayValue(tmpl)
 	if err != nil {
@@ -212,12 +237,13 @@ func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
 			return match
 		}
 		parts := placeholder.FindStringSubmatch(match)
-		if len(parts) < 4 {
+		if len(parts) < 5 {
 			return match
 		}
 		flags := parts[1]
 		width := parts[2]
-		verb := parts[3]
+		precision := parts[3]
+		verb := parts[4]
 		// verbs without replacement
 		switch verb {
 		case "%":
@@ -230,7 +256,17 @@ func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
 			value = replacements[i]
 		}
 		i += 1
+		p := -1
+		if precision != "" {
+			parsed, parseErr := strconv.Atoi(precision)
+			if parseErr != nil || parsed > 100 {
+				err = fail("format " + match + " has a precision above 100")
+				return match
+			}
+			p = parsed
+		}
 		var result string
+		numeric := false
 		switch verb {
 		case "s":
 			result, err = DisplayValue(value)
@@ -242,13 +278,20 @@ func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
 			if err != nil {
 				return match
 			}
+		case "d", "f", "e":
+			result, numeric, err = formatNumber(fail, match, value, verb, p)
+			if err != nil {
+				return match
+			}
 		default:
-			err = NewFatalError("format " + match + " has unknown verb " + verb)
+			err = fail("format " + match + " has unknown verb " + verb)
 			return match
 		}
 		pad := true
 		padRight := false
 		trunc := false
+		zeros := false
+		sign := false
 		for _, flag := range flags {
 			switch flag {
 			case '*':
@@ -257,11 +300,22 @@ func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
 				padRight = true
 			case '<':
 				trunc = true
+			case '0':
+				zeros = true
+			case '+':
+				sign = true
 			default:
-				err = NewFatalError("format " + match + " has unknown flag " + string(flag))
+				err = fail("format " + match + " has unknown flag " + string(flag))
 				return match
 			}
 		}
+		if numeric {
+			if sign && !strings.HasPrefix(result, "-") {
+				result = "+" + result
+			}
+		} else if p >= 0 && len([]rune(result)) > p {
+			result = string([]rune(result)[0:p])
+		}
 		var w int
 		if parsed, err := strconv.ParseInt(width, 10, 64); width != "" && err == nil {
 			w = int(parsed)
@@ -269,11 +323,17 @@ func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
 		if w > 0 {
 			rl := len([]rune(result))
 			if pad && rl < w {
-				padding := strings.Repeat(" ", w-rl)
-				if padRight {
-					result = result + padding
+				if numeric && zeros && !padRight {
+					// Insert the zeros between the sign and the digits
+					digits := strings.TrimLeft(result, "+-")
+					result = result[:len(result)-len(digits)] + strings.Repeat("0", w-rl) + digits
 				} else {
-					result = padding + result
+					padding := strings.Repeat(" ", w-rl)
+					if padRight {
+						result = result + padding
+					} else {
+						result = padding + result
+					}
 				}
 			} else if trunc && rl > w {
 				result = string([]rune(result)[0:w-1]) + "…"
@@ -287,6 +347,142 @@ func Template(tmpl any, replacements ...any) (string, jpl.JPLError) {
 	return result, nil
 }
 
+// Format the specified replacement for the numeric verbs `d`, `f` and `e`.
+// Numbers that are not finite are formatted as JSON values and are not considered to be numeric.
+func formatNumber(fail func(message string) jpl.JPLError, match string, value any, verb string, precision int) (string, bool, jpl.JPLError) {
+	u, err := UnwrapValue(value)
+	if err != nil {
+		return "", false, err
+	}
+	n, ok := u.(float64)
+	if !ok {
+		t, err := Type(u)
+		if err != nil {
+			return "", false, err
+		}
+		message, err := Template("%s (%*<100v) cannot be formatted by %s", string(t), u, match)
+		if err != nil {
+			return "", false, err
+		}
+		return "", false, fail(message)
+	}
+	if math.IsInf(n, 0) || math.IsNaN(n) {
+		result, err := StrictDisplayValue(n)
+		return result, false, err
+	}
+	if precision < 0 {
+		precision = 6
+	}
+	switch verb {
+	case "d":
+		// Adding zero normalizes negative zero
+		return strconv.FormatFloat(math.Round(n)+0, 'f', 0, 64), true, nil
+	case "f":
+		if math.Abs(n) >= 1e21 {
+			result, err := StrictDisplayValue(n)
+			return result, true, err
+		}
+		return FormatFixed(n, precision), true, nil
+	default:
+		return FormatExponential(n, precision), true, nil
+	}
+}
+
+// Format the specified number in fixed-point notation with the specified number of decimals.
+// Like JavaScript's `Number.prototype.toFixed`, the exact value of the number is rounded half up.
+func FormatFixed(n float64, precision int) string {
+	// Any float64 has at most 1074 decimals, so this is its exact value
+	exact := new(big.Float).SetFloat64(math.Abs(n)).Text('f', 1100)
+	integer, fraction, _ := strings.Cut(exact, ".")
+	digits := integer + fraction[:precision]
+	if fraction[precision] >= '5' {
+		digits = incrementDigits(digits)
+	}
+	if precision > 0 {
+		digits = digits[:len(digits)-precision] + "." + digits[len(digits)-precision:]
+	}
+	if n < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Format the specified number in exponential notation with the specified number of decimals.
+// Like JavaScript's `Number.prototype.toExponential`, the exact value of the number is rounded half up.
+func FormatExponential(n float64, precision int) string {
+	digits := strings.Repeat("0", precision+1)
+	exponent := 0
+	if n != 0 {
+		// Any float64 has at most 767 significant digits, so this is its exact value
+		exact := new(big.Float).SetFloat64(math.Abs(n)).Text('e', 800)
+		mantissa, e, _ := strings.Cut(exact, "e")
+		exponent, _ = strconv.Atoi(e)
+		all := strings.Replace(mantissa, ".", "", 1)
+		digits = all[:precision+1]
+		if all[precision+1] >= '5' {
+			digits = incrementDigits(digits)
+			if len(digits) > precision+1 {
+				digits = digits[:precision+1]
+				exponent += 1
+			}
+		}
+	}
+
+	var b strings.Builder
+	if n < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:1])
+	if precision > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[1:])
+	}
+	b.WriteByte('e')
+	if exponent >= 0 {
+		b.WriteByte('+')
+	}
+	b.WriteString(strconv.Itoa(exponent))
+	return b.String()
+}
+
+// Format the specified number with the specified number of significant digits, which must be at least one.
+// Like JavaScript's `Number.prototype.toPrecision`, the exact value of the number is rounded half up
+// and exponential notation is used for exponents less than -6 or not less than the number of significant digits.
+func FormatPrecision(n float64, precision int) string {
+	result := FormatExponential(n, precision-1)
+	_, e, _ := strings.Cut(result, "e")
+	exponent, _ := strconv.Atoi(e)
+	if exponent < -6 || exponent >= precision {
+		return result
+	}
+	return FormatFixed(n, precision-1-exponent)
+}
+
+// Format the specified number in exponential notation with as many decimals as necessary to represent it uniquely,
+// like JavaScript's `Number.prototype.toExponential` without fraction digits.
+func FormatShortestExponential(n float64) string {
+	// Adding zero normalizes negative zero
+	mantissa, e, _ := strings.Cut(strconv.FormatFloat(n+0, 'e', -1, 64), "e")
+	exponent, _ := strconv.Atoi(e)
+	if exponent >= 0 {
+		return mantissa + "e+" + strconv.Itoa(exponent)
+	}
+	return mantissa + "e" + strconv.Itoa(exponent)
+}
+
+// Increment the specified string of decimal digits by one
+func incrementDigits(digits string) string {
+	b := []byte(digits)
+	for i := len(b) - 1; i >= 0; i -= 1 {
+		if b[i] < '9' {
+			b[i] += 1
+			return string(b)
+		}
+		b[i] = '0'
+	}
+	return "1" + string(b)
+}
+
 // Format the specified normalized value as a string
 func DisplayValue(value any) (string, jpl.JPLError) {
 	return Stringify(value, true, true)
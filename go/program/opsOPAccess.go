@@ -59,8 +59,9 @@ func (opAccess) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (re
 type JPLOPAHandler = jpl.JPLOPSubHandler[definition.JPLSelectorParams, jpl.JPLSelectorParams]
 
 var opas = map[definition.JPLOPA]JPLOPAHandler{
-	definition.OPA_FIELD:    opaField{},
-	definition.OPA_FUNCTION: opaFunction{},
-	definition.OPA_ITER:     opaIter{},
-	definition.OPA_SLICE:    opaSlice{},
+	definition.OPA_FIELD:             opaField{},
+	definition.OPA_FUNCTION:          opaFunction{},
+	definition.OPA_ITER:              opaIter{},
+	definition.OPA_RECURSIVE_DESCENT: opaRecursiveDescent{},
+	definition.OPA_SLICE:             opaSlice{},
 }
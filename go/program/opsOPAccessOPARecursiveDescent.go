@@ -0,0 +1,140 @@
+package program
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opaRecursiveDescent struct{}
+
+// { pipe: [op] | null, optional: boolean }
+func (opaRecursiveDescent) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, next jpl.JPLPiper) ([]any, jpl.JPLError) {
+	fields, err := recursiveDescentFields(runtime, input, params, scope, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return walkRecursiveDescent(scope.Signal(), pathTarget{value: target}, fields, params.Pipe == nil, func(t pathTarget) ([]any, jpl.JPLError) {
+		return next.Pipe(t.value)
+	})
+}
+
+// { pipe: function | null, optional: boolean }
+func (opaRecursiveDescent) Map(runtime jpl.JPLRuntime, params jpl.JPLSelectorParams) (result definition.JPLSelectorParams, err jpl.JPLError) {
+	if params.Pipe != nil {
+		result.Pipe = call(params.Pipe)
+	}
+	result.Optional = params.Optional
+	return
+}
+
+// Resolve the fields that are matched by a recursive descent.
+// Strings match object fields, whereas numbers match array items.
+func recursiveDescentFields(runtime jpl.JPLRuntime, input any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, suffix string) ([]any, jpl.JPLError) {
+	if params.Pipe == nil {
+		return nil, nil
+	}
+
+	return runtime.ExecuteInstructions(params.Pipe, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		field, err := library.UnwrapValue(output)
+		if err != nil {
+			return nil, err
+		}
+		tf, err := library.Type(field)
+		if err != nil {
+			return nil, err
+		}
+		switch tf {
+		case jpl.JPLT_STRING, jpl.JPLT_NUMBER:
+			return []any{field}, nil
+
+		default:
+		}
+
+		if params.Optional {
+			return nil, nil
+		}
+		return nil, library.ThrowAny(library.NewTypeError("cannot descend recursively into fields with %s (%*<100v)"+suffix, string(tf), field))
+	}))
+}
+
+// Visit all values below the specified target in pre-order, which either match one of the specified fields or, if all is set, any value.
+// Array items are visited by index, object fields are visited in sorted key order.
+func walkRecursiveDescent(signal jpl.JPLRuntimeSignal, target pathTarget, fields []any, all bool, cb func(t pathTarget) ([]any, jpl.JPLError)) ([]any, jpl.JPLError) {
+	if err := signal.CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	value, err := library.UnwrapValue(target.value)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []any
+	var children []pathTarget
+	switch v := value.(type) {
+	case map[string]any:
+		for _, field := range fields {
+			if key, ok := field.(string); ok {
+				if item, ok := v[key]; ok {
+					outputs, err := cb(target.with(key, item))
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, outputs...)
+				}
+			}
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			children = append(children, target.with(key, v[key]))
+		}
+
+	case []any:
+		for _, field := range fields {
+			if index, ok := field.(float64); ok {
+				i := int(index)
+				if i < 0 {
+					i = len(v) + i
+				}
+				if i >= 0 && i < len(v) {
+					outputs, err := cb(target.with(float64(i), v[i]))
+					if err != nil {
+						return nil, err
+					}
+					results = append(results, outputs...)
+				}
+			}
+		}
+
+		for i, item := range v {
+			children = append(children, target.with(float64(i), item))
+		}
+
+	default:
+	}
+
+	for _, child := range children {
+		if all {
+			outputs, err := cb(child)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, outputs...)
+		}
+		outputs, err := walkRecursiveDescent(signal, child, fields, all, cb)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, outputs...)
+	}
+	return results, nil
+}
@@ -96,9 +96,10 @@ func (opAssignment) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams)
 }
 
 var opasAssign = map[definition.JPLOPA]JPLOPAHandler{
-	definition.OPA_FIELD: opaAssignField{},
-	definition.OPA_ITER:  opaAssignIter{},
-	definition.OPA_SLICE: opaAssignSlice{},
+	definition.OPA_FIELD:             opaAssignField{},
+	definition.OPA_ITER:              opaAssignIter{},
+	definition.OPA_RECURSIVE_DESCENT: opaAssignRecursiveDescent{},
+	definition.OPA_SLICE:             opaAssignSlice{},
 }
 
 type JPLOPUHandler = jpl.JPLOPSubHandler[definition.JPLAssignmentParams, jpl.JPLAssignmentParams]
@@ -0,0 +1,133 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opaAssignRecursiveDescent struct{}
+
+// { pipe: [op] | null, optional: boolean }
+//
+// Nested values are assigned before the values that contain them.
+func (opaAssignRecursiveDescent) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, next jpl.JPLPiper) ([]any, jpl.JPLError) {
+	fields, err := recursiveDescentFields(runtime, input, params, scope, " (assignment)")
+	if err != nil {
+		return nil, err
+	}
+	all := params.Pipe == nil
+
+	var assignFields func(from int, source any) ([]any, jpl.JPLError)
+	assignFields = func(from int, source any) ([]any, jpl.JPLError) {
+		if from >= len(fields) {
+			return []any{source}, nil
+		}
+
+		var item any
+		var apply func(value any, output any) any
+		s, err := library.UnwrapValue(source)
+		if err != nil {
+			return nil, err
+		}
+		switch v := s.(type) {
+		case map[string]any:
+			if key, ok := fields[from].(string); ok {
+				if value, ok := v[key]; ok {
+					item = value
+					apply = func(value any, output any) any {
+						return library.ApplyObject(value.(map[string]any), []*library.ObjectEntry[any]{{Key: key, Value: output}})
+					}
+				}
+			}
+
+		case []any:
+			if index, ok := fields[from].(float64); ok {
+				i := int(index)
+				if i < 0 {
+					i = len(v) + i
+				}
+				if i >= 0 && i < len(v) {
+					item = v[i]
+					apply = func(value any, output any) any {
+						return library.ApplyArray(value.([]any), []*library.ArrayEntry[any]{{Index: i, Value: output}}, nil)
+					}
+				}
+			}
+
+		default:
+		}
+
+		if apply == nil {
+			return assignFields(from+1, source)
+		}
+
+		values, err := next.Pipe(item)
+		if err != nil {
+			return nil, err
+		}
+		return library.MuxAll([][]any{values}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+			output := args[0]
+			if _, ok := output.(unchanged); ok {
+				return assignFields(from+1, source)
+			}
+			result, err := library.AlterValue(source, jpl.JPLModifierFunc(func(value any) (any, jpl.JPLError) { return apply(value, output), nil }))
+			if err != nil {
+				return nil, err
+			}
+			return assignFields(from+1, result)
+		}))
+	}
+
+	var visit func(value any) ([]any, jpl.JPLError)
+	visit = func(value any) ([]any, jpl.JPLError) {
+		if err := scope.Signal().CheckHealth(); err != nil {
+			return nil, err
+		}
+
+		v, err := library.UnwrapValue(value)
+		if err != nil {
+			return nil, err
+		}
+		t, err := library.Type(v)
+		if err != nil {
+			return nil, err
+		}
+		if t != jpl.JPLT_OBJECT && t != jpl.JPLT_ARRAY {
+			return []any{value}, nil
+		}
+
+		results, err := opaAssignIter{}.OP(runtime, input, value, definition.JPLSelectorParams{}, scope, jpl.JPLPiperFunc(func(item any) ([]any, jpl.JPLError) {
+			values, err := visit(item)
+			if err != nil || !all {
+				return values, err
+			}
+			return library.MuxAll([][]any{values}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+				visited := args[0]
+				outputs, err := next.Pipe(visited)
+				if err != nil {
+					return nil, err
+				}
+				return library.MuxOne([][]any{outputs}, jpl.IOMuxerFunc[any, any](func(args ...any) (any, jpl.JPLError) {
+					if _, ok := args[0].(unchanged); ok {
+						return visited, nil
+					}
+					return args[0], nil
+				}))
+			}))
+		}))
+		if err != nil || all {
+			return results, err
+		}
+		return library.MuxAll([][]any{results}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+			return assignFields(0, args[0])
+		}))
+	}
+
+	return visit(target)
+}
+
+// { pipe: function | null, optional: boolean }
+func (opaAssignRecursiveDescent) Map(runtime jpl.JPLRuntime, params jpl.JPLSelectorParams) (result definition.JPLSelectorParams, err jpl.JPLError) {
+	return opaRecursiveDescent{}.Map(runtime, params)
+}
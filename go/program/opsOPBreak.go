@@ -0,0 +1,50 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opBreak struct{}
+
+// Scope that keeps track of the runtime areas of all labels that can be broken out of.
+// This is not part of JPLRuntimeScope, so that scopes that do not support labels remain valid.
+type labeledScope interface {
+	Labels() map[string]jpl.JPLRuntimeSignal
+}
+
+// { name: string }
+func (opBreak) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	var labels map[string]jpl.JPLRuntimeSignal
+	if labeled, ok := scope.(labeledScope); ok {
+		labels = labeled.Labels()
+	}
+	signal, ok := labels[params.Name]
+	if !ok {
+		return nil, library.ThrowAny(library.NewReferenceError("label %s is not defined", params.Name))
+	}
+	// Breaking out of a label is only possible from within its own runtime area
+	if !isWithin(scope.Signal(), signal) {
+		return nil, library.ThrowAny(library.NewRuntimeError("label %s has already been exited", params.Name))
+	}
+	// Exiting the label's runtime area aborts its execution, which is then handled by the label itself
+	signal.Exit()
+	return nil, signal.CheckHealth()
+}
+
+// { name: string }
+func (opBreak) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	return definition.JPLInstructionParams{
+		Name: params.Name,
+	}, nil
+}
+
+func isWithin(signal jpl.JPLRuntimeSignal, area jpl.JPLRuntimeSignal) bool {
+	for current := signal; current != nil; current = current.Parent() {
+		if current == area {
+			return true
+		}
+	}
+	return false
+}
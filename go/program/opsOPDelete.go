@@ -0,0 +1,101 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+// Concrete path to a value, which is passed between the deletion selectors
+type pathTarget struct {
+	path  []any
+	value any
+}
+
+func (t pathTarget) with(key any, value any) pathTarget {
+	path := make([]any, len(t.path), len(t.path)+1)
+	copy(path, t.path)
+	return pathTarget{path: append(path, key), value: value}
+}
+
+type opDelete struct{}
+
+// { paths: [[opa]] }
+func (opDelete) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	var iter func(selectors []definition.JPLSelector, from int, target pathTarget) ([]any, jpl.JPLError)
+	iter = func(selectors []definition.JPLSelector, from int, target pathTarget) ([]any, jpl.JPLError) {
+		if err := scope.Signal().CheckHealth(); err != nil {
+			return nil, err
+		}
+
+		if from >= len(selectors) {
+			return []any{target.path}, nil
+		}
+
+		selector := selectors[from]
+		operator, ok := opasDelete[selector.OP]
+		if !ok {
+			return nil, library.NewFatalError("invalid OPA '" + string(selector.OP) + "' (deletion)")
+		}
+
+		return operator.OP(runtime, input, target, selector.Params, scope, jpl.JPLPiperFunc(func(output any) ([]any, jpl.JPLError) {
+			return iter(selectors, from+1, output.(pathTarget))
+		}))
+	}
+
+	results, err := library.MuxAll([][][]definition.JPLSelector{params.Paths}, jpl.IOMuxerFunc[[]definition.JPLSelector, []any](func(args ...[]definition.JPLSelector) ([]any, jpl.JPLError) {
+		return iter(args[0], 0, pathTarget{value: input})
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	var paths [][]any
+	for _, result := range results {
+		path := result.([]any)
+		// Trailing slices are expanded into their items, so that they are removed alongside all other paths
+		if l := len(path); l > 0 {
+			if slice, ok := path[l-1].(map[string]any); ok {
+				for i := slice["from"].(float64); i < slice["to"].(float64); i += 1 {
+					paths = append(paths, append(library.CopySlice(path[:l-1]), i))
+				}
+				continue
+			}
+		}
+		paths = append(paths, path)
+	}
+
+	result, err := library.DeletePaths(runtime, scope.Signal(), input, paths)
+	if err != nil {
+		return nil, err
+	}
+	return next.Pipe(result, scope)
+}
+
+// { paths: [[opa]] }
+func (opDelete) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	result.Paths, err = library.MuxOne([][][]jpl.JPLSelector{params.Paths}, jpl.IOMuxerFunc[[]jpl.JPLSelector, []definition.JPLSelector](func(args ...[]jpl.JPLSelector) ([]definition.JPLSelector, jpl.JPLError) {
+		return library.MuxOne([][]jpl.JPLSelector{args[0]}, jpl.IOMuxerFunc[jpl.JPLSelector, definition.JPLSelector](func(args ...jpl.JPLSelector) (result definition.JPLSelector, err jpl.JPLError) {
+			selector := args[0]
+			operator, ok := opasDelete[selector.OP]
+			if !ok {
+				err = library.NewFatalError("invalid OPA '" + string(selector.OP) + "' (deletion)")
+				return
+			}
+
+			result.OP = selector.OP
+			if result.Params, err = operator.Map(runtime, selector.Params); err != nil {
+				return
+			}
+			return
+		}))
+	}))
+	return
+}
+
+var opasDelete = map[definition.JPLOPA]JPLOPAHandler{
+	definition.OPA_FIELD:             opaDeleteField{},
+	definition.OPA_ITER:              opaDeleteIter{},
+	definition.OPA_RECURSIVE_DESCENT: opaDeleteRecursiveDescent{},
+	definition.OPA_SLICE:             opaDeleteSlice{},
+}
@@ -0,0 +1,90 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opaDeleteField struct{}
+
+// { pipe: [op], optional: boolean }
+func (opaDeleteField) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, next jpl.JPLPiper) ([]any, jpl.JPLError) {
+	t := target.(pathTarget)
+	value, err := library.UnwrapValue(t.value)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime.ExecuteInstructions(params.Pipe, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		field, err := library.UnwrapValue(output)
+		if err != nil {
+			return nil, err
+		}
+		tf, err := library.Type(field)
+		if err != nil {
+			return nil, err
+		}
+		switch tv {
+		case jpl.JPLT_NULL:
+			if tf == jpl.JPLT_STRING || tf == jpl.JPLT_NUMBER {
+				return next.Pipe(t.with(field, nil))
+			}
+
+		case jpl.JPLT_OBJECT:
+			if tf == jpl.JPLT_STRING {
+				return next.Pipe(t.with(field, value.(map[string]any)[field.(string)]))
+			}
+
+		case jpl.JPLT_ARRAY:
+			if tf == jpl.JPLT_NUMBER {
+				v := value.([]any)
+				i := int(field.(float64))
+				if i < 0 {
+					i = len(v) + i
+				}
+				if i < 0 {
+					return nil, nil
+				}
+				var item any
+				if i < len(v) {
+					item = v[i]
+				}
+				return next.Pipe(t.with(float64(i), item))
+			}
+
+		case jpl.JPLT_STRING:
+			if tf == jpl.JPLT_NUMBER {
+				chars := []rune(value.(string))
+				i := int(field.(float64))
+				if i < 0 {
+					i = len(chars) + i
+				}
+				if i < 0 {
+					return nil, nil
+				}
+				var item any
+				if i < len(chars) {
+					item = string(chars[i])
+				}
+				return next.Pipe(t.with(float64(i), item))
+			}
+
+		default:
+		}
+
+		if params.Optional {
+			return nil, nil
+		}
+		return nil, library.ThrowAny(library.NewTypeError("cannot access field of %s (%*<100v) with %s (%*<100v) (deletion)", string(tv), value, string(tf), field))
+	}))
+}
+
+// { pipe: function, optional: boolean }
+func (opaDeleteField) Map(runtime jpl.JPLRuntime, params jpl.JPLSelectorParams) (result definition.JPLSelectorParams, err jpl.JPLError) {
+	return opaField{}.Map(runtime, params)
+}
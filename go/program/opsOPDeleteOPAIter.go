@@ -0,0 +1,53 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opaDeleteIter struct{}
+
+// { optional: boolean }
+func (opaDeleteIter) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, next jpl.JPLPiper) ([]any, jpl.JPLError) {
+	t := target.(pathTarget)
+	value, err := library.UnwrapValue(t.value)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	switch tv {
+	case jpl.JPLT_NULL:
+		return nil, nil
+
+	case jpl.JPLT_OBJECT:
+		return library.MuxAll([][]*library.ObjectEntry[any]{library.ObjectEntries(value.(map[string]any))}, jpl.IOMuxerFunc[*library.ObjectEntry[any], []any](func(args ...*library.ObjectEntry[any]) ([]any, jpl.JPLError) {
+			return next.Pipe(t.with(args[0].Key, args[0].Value))
+		}))
+
+	case jpl.JPLT_ARRAY:
+		return library.MuxAll([][]*library.ArrayEntry[any]{library.ArrayEntries(value.([]any))}, jpl.IOMuxerFunc[*library.ArrayEntry[any], []any](func(args ...*library.ArrayEntry[any]) ([]any, jpl.JPLError) {
+			return next.Pipe(t.with(float64(args[0].Index), args[0].Value))
+		}))
+
+	case jpl.JPLT_STRING:
+		return library.MuxAll([][]*library.ArrayEntry[rune]{library.ArrayEntries([]rune(value.(string)))}, jpl.IOMuxerFunc[*library.ArrayEntry[rune], []any](func(args ...*library.ArrayEntry[rune]) ([]any, jpl.JPLError) {
+			return next.Pipe(t.with(float64(args[0].Index), string(args[0].Value)))
+		}))
+
+	default:
+	}
+
+	if params.Optional {
+		return nil, nil
+	}
+	return nil, library.ThrowAny(library.NewTypeError("cannot iterate over %s (%*<100v) (deletion)", string(tv), value))
+}
+
+// { optional: boolean }
+func (opaDeleteIter) Map(runtime jpl.JPLRuntime, params jpl.JPLSelectorParams) (result definition.JPLSelectorParams, err jpl.JPLError) {
+	return opaIter{}.Map(runtime, params)
+}
@@ -0,0 +1,25 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+type opaDeleteRecursiveDescent struct{}
+
+// { pipe: [op] | null, optional: boolean }
+func (opaDeleteRecursiveDescent) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, next jpl.JPLPiper) ([]any, jpl.JPLError) {
+	fields, err := recursiveDescentFields(runtime, input, params, scope, " (deletion)")
+	if err != nil {
+		return nil, err
+	}
+
+	return walkRecursiveDescent(scope.Signal(), target.(pathTarget), fields, params.Pipe == nil, func(t pathTarget) ([]any, jpl.JPLError) {
+		return next.Pipe(t)
+	})
+}
+
+// { pipe: function | null, optional: boolean }
+func (opaDeleteRecursiveDescent) Map(runtime jpl.JPLRuntime, params jpl.JPLSelectorParams) (result definition.JPLSelectorParams, err jpl.JPLError) {
+	return opaRecursiveDescent{}.Map(runtime, params)
+}
@@ -0,0 +1,103 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opaDeleteSlice struct{}
+
+// { from: [op], to: [op], optional: boolean }
+func (opaDeleteSlice) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLSelectorParams, scope jpl.JPLRuntimeScope, next jpl.JPLPiper) ([]any, jpl.JPLError) {
+	t := target.(pathTarget)
+	value, err := library.UnwrapValue(t.value)
+	if err != nil {
+		return nil, err
+	}
+	tv, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+
+	froms, err := runtime.ExecuteInstructions(params.From, []any{input}, scope, nil)
+	if err != nil {
+		return nil, err
+	}
+	tos, err := runtime.ExecuteInstructions(params.To, []any{input}, scope, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	unwrappedFroms, err := library.UnwrapValues(froms, "")
+	if err != nil {
+		return nil, err
+	}
+	unwrappedTos, err := library.UnwrapValues(tos, "")
+	if err != nil {
+		return nil, err
+	}
+	return library.MuxAll([][]any{unwrappedFroms, unwrappedTos}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+		from := args[0]
+		to := args[1]
+		tf, err := library.Type(from)
+		if err != nil {
+			return nil, err
+		}
+		tt, err := library.Type(to)
+		if err != nil {
+			return nil, err
+		}
+		if (tf == jpl.JPLT_NUMBER || tf == jpl.JPLT_NULL) && (tt == jpl.JPLT_NUMBER || tt == jpl.JPLT_NULL) {
+			switch tv {
+			case jpl.JPLT_NULL:
+				return nil, nil
+
+			case jpl.JPLT_ARRAY:
+				v := value.([]any)
+				s, e := sliceBounds(len(v), from, to)
+				return next.Pipe(t.with(map[string]any{"from": float64(s), "to": float64(e)}, v[s:e]))
+
+			case jpl.JPLT_STRING:
+				chars := []rune(value.(string))
+				s, e := sliceBounds(len(chars), from, to)
+				return next.Pipe(t.with(map[string]any{"from": float64(s), "to": float64(e)}, string(chars[s:e])))
+
+			default:
+			}
+		}
+
+		if params.Optional {
+			return nil, nil
+		}
+		return nil, library.ThrowAny(library.NewTypeError("cannot slice %s (%*<100v) with %s (%*<100v) and %s (%*<100v) (deletion)", string(tv), value, string(tf), from, string(tt), to))
+	}))
+}
+
+// Resolve the absolute bounds of the slice `[from:to]` for the specified length
+func sliceBounds(l int, from any, to any) (s int, e int) {
+	s = 0
+	if f, ok := from.(float64); ok {
+		s = int(f)
+	}
+	e = l
+	if t, ok := to.(float64); ok {
+		e = int(t)
+	}
+	if s >= 0 {
+		s = min(l, s)
+	} else {
+		s = max(0, l+s)
+	}
+	if e >= 0 {
+		e = min(l, e)
+	} else {
+		e = max(0, l+e)
+	}
+	return s, max(s, e)
+}
+
+// { from: function, to: function, optional: boolean }
+func (opaDeleteSlice) Map(runtime jpl.JPLRuntime, params jpl.JPLSelectorParams) (result definition.JPLSelectorParams, err jpl.JPLError) {
+	return opaSlice{}.Map(runtime, params)
+}
@@ -0,0 +1,142 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opDestructuringDefinition struct{}
+
+// { pattern: opd, pipe: [op] }
+func (opDestructuringDefinition) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	if params.Pattern == nil {
+		return nil, library.NewFatalError("missing pattern")
+	}
+
+	return runtime.ExecuteInstructions(params.Pipe, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		return bindPattern(runtime, input, output, *params.Pattern, scope, jpl.JPLScopedPiperFunc(func(_ any, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+			return next.Pipe(input, scope)
+		}), func(cause func() jpl.JPLError) ([]any, jpl.JPLError) {
+			return nil, cause()
+		})
+	}))
+}
+
+// { pattern: opd, pipe: function }
+func (opDestructuringDefinition) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	if params.Pattern == nil {
+		err = library.NewFatalError("missing pattern")
+		return
+	}
+
+	var pattern definition.JPLPattern
+	if pattern, err = mapPattern(runtime, *params.Pattern); err != nil {
+		return
+	}
+	result.Pattern = &pattern
+	result.Pipe = call(params.Pipe)
+	return
+}
+
+// Handler for targets that do not match a pattern.
+// It decides whether the mismatch results in the specified error or whether the target is skipped.
+type JPLPatternMismatch func(cause func() jpl.JPLError) ([]any, jpl.JPLError)
+
+type JPLOPDHandler interface {
+	OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLPatternParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError)
+
+	Map(runtime jpl.JPLRuntime, params jpl.JPLPatternParams) (definition.JPLPatternParams, jpl.JPLError)
+}
+
+var opds = map[definition.JPLOPD]JPLOPDHandler{
+	definition.OPD_ARRAY:    opdArray{},
+	definition.OPD_OBJECT:   opdObject{},
+	definition.OPD_TYPE:     opdType{},
+	definition.OPD_VALUE:    opdValue{},
+	definition.OPD_VARIABLE: opdVariable{},
+}
+
+// Bind the specified target to the pattern and pass the resulting scope to next
+func bindPattern(runtime jpl.JPLRuntime, input any, target any, pattern definition.JPLPattern, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError) {
+	if err := scope.Signal().CheckHealth(); err != nil {
+		return nil, err
+	}
+
+	operator, ok := opds[pattern.OP]
+	if !ok {
+		return nil, library.NewFatalError("invalid OPD '" + string(pattern.OP) + "'")
+	}
+
+	return operator.OP(runtime, input, target, pattern.Params, scope, next, mismatch)
+}
+
+// Bind the default value of the specified entry, whose target is missing.
+// Optional entries without a default value bind null to all of their variables.
+func bindMissingEntry(runtime jpl.JPLRuntime, input any, entry definition.JPLPatternEntry, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch, cause func() jpl.JPLError) ([]any, jpl.JPLError) {
+	if entry.Default != nil {
+		return runtime.ExecuteInstructions(entry.Default, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+			return bindPattern(runtime, input, output, entry.Pattern, scope, next, mismatch)
+		}))
+	}
+
+	if !entry.Optional {
+		return mismatch(cause)
+	}
+
+	vars := map[string]any{}
+	for _, name := range patternVariables(entry.Pattern) {
+		vars[name] = nil
+	}
+	return next.Pipe(nil, scope.Next(&jpl.JPLRuntimeScopeConfig{Vars: vars}))
+}
+
+// Resolve the names of all variables that are bound by the specified pattern
+func patternVariables(pattern definition.JPLPattern) (names []string) {
+	if pattern.Params.Name != "" {
+		names = append(names, pattern.Params.Name)
+	}
+	if pattern.Params.Pattern != nil {
+		names = append(names, patternVariables(*pattern.Params.Pattern)...)
+	}
+	for _, entry := range pattern.Params.Entries {
+		names = append(names, patternVariables(entry.Pattern)...)
+	}
+	if pattern.Params.Rest != "" {
+		names = append(names, pattern.Params.Rest)
+	}
+	return
+}
+
+// Map the specified pattern and its sub patterns
+func mapPattern(runtime jpl.JPLRuntime, pattern jpl.JPLPattern) (result definition.JPLPattern, err jpl.JPLError) {
+	operator, ok := opds[pattern.OP]
+	if !ok {
+		err = library.NewFatalError("invalid OPD '" + string(pattern.OP) + "'")
+		return
+	}
+
+	result.OP = pattern.OP
+	if result.Params, err = operator.Map(runtime, pattern.Params); err != nil {
+		return
+	}
+	return
+}
+
+// Map the specified pattern entries, optionally including their keys
+func mapPatternEntries(runtime jpl.JPLRuntime, entries []jpl.JPLPatternEntry, keys bool) ([]definition.JPLPatternEntry, jpl.JPLError) {
+	return library.MuxOne([][]jpl.JPLPatternEntry{entries}, jpl.IOMuxerFunc[jpl.JPLPatternEntry, definition.JPLPatternEntry](func(args ...jpl.JPLPatternEntry) (result definition.JPLPatternEntry, err jpl.JPLError) {
+		entry := args[0]
+		if keys {
+			result.Key = call(entry.Key)
+		}
+		if result.Pattern, err = mapPattern(runtime, entry.Pattern); err != nil {
+			return
+		}
+		if entry.Default != nil {
+			result.Default = call(entry.Default)
+		}
+		result.Optional = entry.Optional
+		return
+	}))
+}
@@ -0,0 +1,70 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opdArray struct{}
+
+// { entries: [{ pattern: opd, default: [op], optional: boolean }], rest: string }
+func (opdArray) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLPatternParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(target)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_ARRAY {
+		return mismatch(func() jpl.JPLError {
+			return library.ThrowAny(library.NewTypeError("cannot destructure %s (%*<100v) as array", string(t), value))
+		})
+	}
+	array := value.([]any)
+
+	var iter func(from int, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError)
+	iter = func(from int, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		if err := scope.Signal().CheckHealth(); err != nil {
+			return nil, err
+		}
+
+		if from >= len(params.Entries) {
+			if params.Rest == "" {
+				return next.Pipe(target, scope)
+			}
+
+			rest := []any{}
+			if from < len(array) {
+				rest = library.CopySlice(array[from:])
+			}
+			return next.Pipe(target, scope.Next(&jpl.JPLRuntimeScopeConfig{Vars: map[string]any{params.Rest: rest}}))
+		}
+
+		entry := params.Entries[from]
+		piper := jpl.JPLScopedPiperFunc(func(_ any, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+			return iter(from+1, scope)
+		})
+
+		if from < len(array) {
+			return bindPattern(runtime, input, array[from], entry.Pattern, scope, piper, mismatch)
+		}
+
+		return bindMissingEntry(runtime, input, entry, scope, piper, mismatch, func() jpl.JPLError {
+			return library.ThrowAny(library.NewTypeError("cannot destructure missing item %d of array (%*<100v)", float64(from), value))
+		})
+	}
+
+	return iter(0, scope)
+}
+
+// { entries: [{ pattern: opd, default: function, optional: boolean }], rest: string }
+func (opdArray) Map(runtime jpl.JPLRuntime, params jpl.JPLPatternParams) (result definition.JPLPatternParams, err jpl.JPLError) {
+	if result.Entries, err = mapPatternEntries(runtime, params.Entries, false); err != nil {
+		return
+	}
+	result.Rest = params.Rest
+	return
+}
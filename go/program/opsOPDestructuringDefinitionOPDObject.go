@@ -0,0 +1,94 @@
+package program
+
+import (
+	"slices"
+
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opdObject struct{}
+
+// { entries: [{ key: [op], pattern: opd, default: [op], optional: boolean }], rest: string }
+func (opdObject) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLPatternParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(target)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if t != jpl.JPLT_OBJECT {
+		return mismatch(func() jpl.JPLError {
+			return library.ThrowAny(library.NewTypeError("cannot destructure %s (%*<100v) as object", string(t), value))
+		})
+	}
+	object := value.(map[string]any)
+
+	var iter func(from int, keys []string, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError)
+	iter = func(from int, keys []string, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		if err := scope.Signal().CheckHealth(); err != nil {
+			return nil, err
+		}
+
+		if from >= len(params.Entries) {
+			if params.Rest == "" {
+				return next.Pipe(target, scope)
+			}
+
+			rest := make(map[string]any, len(object))
+			for k, v := range object {
+				if !slices.Contains(keys, k) {
+					rest[k] = v
+				}
+			}
+			return next.Pipe(target, scope.Next(&jpl.JPLRuntimeScopeConfig{Vars: map[string]any{params.Rest: rest}}))
+		}
+
+		entry := params.Entries[from]
+		piper := func(keys []string) jpl.JPLScopedPiper {
+			return jpl.JPLScopedPiperFunc(func(_ any, scope jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+				return iter(from+1, keys, scope)
+			})
+		}
+
+		return runtime.ExecuteInstructions(entry.Key, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+			key, err := library.UnwrapValue(output)
+			if err != nil {
+				return nil, err
+			}
+			tk, err := library.Type(key)
+			if err != nil {
+				return nil, err
+			}
+			if tk != jpl.JPLT_STRING {
+				return bindMissingEntry(runtime, input, entry, scope, piper(keys), mismatch, func() jpl.JPLError {
+					return library.ThrowAny(library.NewTypeError("cannot use %s (%*<100v) as object key", string(tk), key))
+				})
+			}
+
+			k := key.(string)
+			nextKeys := append(library.CopySlice(keys), k)
+			if v, ok := object[k]; ok {
+				return bindPattern(runtime, input, v, entry.Pattern, scope, piper(nextKeys), mismatch)
+			}
+
+			return bindMissingEntry(runtime, input, entry, scope, piper(nextKeys), mismatch, func() jpl.JPLError {
+				return library.ThrowAny(library.NewTypeError("cannot destructure missing field %*<100v of object (%*<100v)", k, value))
+			})
+		}))
+	}
+
+	return iter(0, nil, scope)
+}
+
+// { entries: [{ key: function, pattern: opd, default: function, optional: boolean }], rest: string }
+func (opdObject) Map(runtime jpl.JPLRuntime, params jpl.JPLPatternParams) (result definition.JPLPatternParams, err jpl.JPLError) {
+	if result.Entries, err = mapPatternEntries(runtime, params.Entries, true); err != nil {
+		return
+	}
+	result.Rest = params.Rest
+	return
+}
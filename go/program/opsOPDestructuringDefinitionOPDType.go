@@ -0,0 +1,44 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opdType struct{}
+
+// { type: string, pattern: opd }
+func (opdType) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLPatternParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError) {
+	value, err := library.UnwrapValue(target)
+	if err != nil {
+		return nil, err
+	}
+	t, err := library.Type(value)
+	if err != nil {
+		return nil, err
+	}
+	if string(t) != params.Type {
+		return mismatch(func() jpl.JPLError {
+			return library.ThrowAny(library.NewTypeError("cannot destructure %s (%*<100v) as %s", string(t), value, params.Type))
+		})
+	}
+
+	if params.Pattern == nil {
+		return next.Pipe(target, scope)
+	}
+	return bindPattern(runtime, input, target, *params.Pattern, scope, next, mismatch)
+}
+
+// { type: string, pattern: opd }
+func (opdType) Map(runtime jpl.JPLRuntime, params jpl.JPLPatternParams) (result definition.JPLPatternParams, err jpl.JPLError) {
+	result.Type = params.Type
+	if params.Pattern != nil {
+		var pattern definition.JPLPattern
+		if pattern, err = mapPattern(runtime, *params.Pattern); err != nil {
+			return
+		}
+		result.Pattern = &pattern
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opdValue struct{}
+
+// { pipe: [op] }
+func (opdValue) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLPatternParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError) {
+	values, err := runtime.ExecuteInstructions(params.Pipe, []any{input}, scope, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		if equals, err := library.Equals(target, value); err != nil {
+			return nil, err
+		} else if equals {
+			return next.Pipe(target, scope)
+		}
+	}
+
+	return mismatch(func() jpl.JPLError {
+		value, err := library.UnwrapValue(target)
+		if err != nil {
+			return err
+		}
+		t, err := library.Type(value)
+		if err != nil {
+			return err
+		}
+		return library.ThrowAny(library.NewTypeError("cannot destructure %s (%*<100v) as expected value", string(t), value))
+	})
+}
+
+// { pipe: function }
+func (opdValue) Map(runtime jpl.JPLRuntime, params jpl.JPLPatternParams) (result definition.JPLPatternParams, err jpl.JPLError) {
+	result.Pipe = call(params.Pipe)
+	return
+}
@@ -0,0 +1,19 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+)
+
+type opdVariable struct{}
+
+// { name: string }
+func (opdVariable) OP(runtime jpl.JPLRuntime, input any, target any, params definition.JPLPatternParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper, mismatch JPLPatternMismatch) ([]any, jpl.JPLError) {
+	return next.Pipe(target, scope.Next(&jpl.JPLRuntimeScopeConfig{Vars: map[string]any{params.Name: target}}))
+}
+
+// { name: string }
+func (opdVariable) Map(runtime jpl.JPLRuntime, params jpl.JPLPatternParams) (result definition.JPLPatternParams, err jpl.JPLError) {
+	result.Name = params.Name
+	return
+}
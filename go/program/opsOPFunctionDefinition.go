@@ -8,15 +8,30 @@ import (
 
 type opFunctionDefinition struct{}
 
-// { argNames: [string], pipe: [op] }
+// { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: [op] }], pipe: [op] }
 func (opFunctionDefinition) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	if params.Args != nil {
+		return next.Pipe(library.TypedFunction(params.Name, params.Args, params.Pipe, scope), scope)
+	}
 	return next.Pipe(library.ScopedFunction(params.ArgNames, params.Pipe, scope), scope)
 }
 
-// { argNames: [string], pipe: function }
+// { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: function }], pipe: function }
 func (opFunctionDefinition) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
-	return definition.JPLInstructionParams{
-		ArgNames: params.ArgNames,
-		Pipe:     call(params.Pipe),
-	}, nil
+	result.Name = params.Name
+	result.ArgNames = params.ArgNames
+	if params.Args != nil {
+		if result.Args, err = library.MuxOne([][]jpl.JPLArgument{params.Args}, jpl.IOMuxerFunc[jpl.JPLArgument, definition.JPLArgument](func(args ...jpl.JPLArgument) (definition.JPLArgument, jpl.JPLError) {
+			arg := args[0]
+			result := definition.JPLArgument{Name: arg.Name, Types: arg.Types, Optional: arg.Optional}
+			if arg.Default != nil {
+				result.Default = call(arg.Default)
+			}
+			return result, nil
+		})); err != nil {
+			return
+		}
+	}
+	result.Pipe = call(params.Pipe)
+	return
 }
@@ -0,0 +1,46 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opLabel struct{}
+
+// { name: string, pipe: [op] }
+func (opLabel) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	signal := scope.Signal().Next()
+	nextScope := scope.Next(&jpl.JPLRuntimeScopeConfig{Signal: signal, Labels: map[string]jpl.JPLRuntimeSignal{params.Name: signal}})
+
+	defer signal.Exit()
+
+	// Results are collected as they are produced, so that they are kept when breaking out of the label
+	var results []any
+	_, err := runtime.ExecuteInstructions(params.Pipe, []any{input}, nextScope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		outputs, err := next.Pipe(output, scope)
+		if err != nil {
+			return nil, library.NewErrorEnclosure(err)
+		}
+		results = append(results, outputs...)
+		return nil, nil
+	}))
+	if err != nil {
+		if errorEnclosure, ok := err.(jpl.JPLErrorEnclosure); ok {
+			return nil, errorEnclosure.JPLEnclosedError()
+		}
+		// The label has been broken out of if its own runtime area has been exited, but not the surrounding one
+		if !signal.Exited() || scope.Signal().Exited() {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// { name: string, pipe: function }
+func (opLabel) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	return definition.JPLInstructionParams{
+		Name: params.Name,
+		Pipe: call(params.Pipe),
+	}, nil
+}
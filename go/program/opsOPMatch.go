@@ -0,0 +1,96 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opMatch struct{}
+
+// { pipe: [op], cases: [{ pattern: opd, if: [op], then: [op] }], else: [op] }
+func (opMatch) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	return runtime.ExecuteInstructions(params.Pipe, []any{input}, scope, jpl.JPLScopedPiperFunc(func(target any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		var iter func(from int) ([]any, jpl.JPLError)
+		iter = func(from int) ([]any, jpl.JPLError) {
+			if err := scope.Signal().CheckHealth(); err != nil {
+				return nil, err
+			}
+
+			if from >= len(params.Cases) {
+				if params.Else == nil {
+					value, err := library.UnwrapValue(target)
+					if err != nil {
+						return nil, err
+					}
+					t, err := library.Type(value)
+					if err != nil {
+						return nil, err
+					}
+					return nil, library.ThrowAny(library.NewRuntimeError("no pattern matches %s (%*<100v)", string(t), value))
+				}
+
+				return runtime.ExecuteInstructions(params.Else, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+					return next.Pipe(output, scope)
+				}))
+			}
+
+			matchCase := params.Cases[from]
+
+			// Collect the scopes of all bindings that satisfy the guard before executing any branch
+			scopes, err := bindPattern(runtime, input, target, matchCase.Pattern, scope, jpl.JPLScopedPiperFunc(func(_ any, caseScope jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+				if matchCase.If == nil {
+					return []any{caseScope}, nil
+				}
+
+				return runtime.ExecuteInstructions(matchCase.If, []any{input}, caseScope, jpl.JPLScopedPiperFunc(func(result any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+					if truthy, err := library.Truthy(result); err != nil {
+						return nil, err
+					} else if truthy {
+						return []any{caseScope}, nil
+					}
+					return nil, nil
+				}))
+			}), func(func() jpl.JPLError) ([]any, jpl.JPLError) {
+				return nil, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			if len(scopes) == 0 {
+				return iter(from + 1)
+			}
+
+			return library.MuxAll([][]any{scopes}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+				return runtime.ExecuteInstructions(matchCase.Then, []any{input}, args[0].(jpl.JPLRuntimeScope), jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+					return next.Pipe(output, scope)
+				}))
+			}))
+		}
+
+		return iter(0)
+	}))
+}
+
+// { pipe: function, cases: [{ pattern: opd, if: function, then: function }], else: function }
+func (opMatch) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	result.Pipe = call(params.Pipe)
+	if result.Cases, err = library.MuxOne([][]jpl.JPLMatchCase{params.Cases}, jpl.IOMuxerFunc[jpl.JPLMatchCase, definition.JPLMatchCase](func(args ...jpl.JPLMatchCase) (result definition.JPLMatchCase, err jpl.JPLError) {
+		entry := args[0]
+		if result.Pattern, err = mapPattern(runtime, entry.Pattern); err != nil {
+			return
+		}
+		if entry.If != nil {
+			result.If = call(entry.If)
+		}
+		result.Then = call(entry.Then)
+		return
+	})); err != nil {
+		return
+	}
+	if params.Else != nil {
+		result.Else = call(params.Else)
+	}
+	return
+}
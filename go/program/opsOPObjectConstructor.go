@@ -8,10 +8,14 @@ import (
 
 type opObjectConstructor struct{}
 
-// { fields: [{ key: [op], value: [op], optional: boolean }] }
+// { fields: [{ key: [op], value: [op], optional: boolean, spread: boolean }] }
 func (opObjectConstructor) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
-	fields, err := library.MuxOne([][]definition.JPLField{params.Fields}, jpl.IOMuxerFunc[definition.JPLField, []*library.ObjectEntry[any]](func(args ...definition.JPLField) ([]*library.ObjectEntry[any], jpl.JPLError) {
+	fields, err := library.MuxOne([][]definition.JPLField{params.Fields}, jpl.IOMuxerFunc[definition.JPLField, [][]*library.ObjectEntry[any]](func(args ...definition.JPLField) ([][]*library.ObjectEntry[any], jpl.JPLError) {
 		field := args[0]
+		if field.Spread {
+			return spreadObjects(runtime, input, field, scope)
+		}
+
 		keys, err := runtime.ExecuteInstructions(field.Key, []any{input}, scope, nil)
 		if err != nil {
 			return nil, err
@@ -25,7 +29,7 @@ func (opObjectConstructor) OP(runtime jpl.JPLRuntime, input any, params definiti
 		if err != nil {
 			return nil, err
 		}
-		return library.MuxAll([][]any{unwrappedKeys, values}, jpl.IOMuxerFunc[any, []*library.ObjectEntry[any]](func(args ...any) ([]*library.ObjectEntry[any], jpl.JPLError) {
+		return library.MuxAll([][]any{unwrappedKeys, values}, jpl.IOMuxerFunc[any, [][]*library.ObjectEntry[any]](func(args ...any) ([][]*library.ObjectEntry[any], jpl.JPLError) {
 			key := args[0]
 			value := args[1]
 			t, err := library.Type(key)
@@ -34,7 +38,7 @@ func (opObjectConstructor) OP(runtime jpl.JPLRuntime, input any, params definiti
 			}
 			switch t {
 			case jpl.JPLT_STRING:
-				return []*library.ObjectEntry[any]{{Key: key.(string), Value: value}}, nil
+				return [][]*library.ObjectEntry[any]{{{Key: key.(string), Value: value}}}, nil
 
 			default:
 			}
@@ -49,20 +53,47 @@ func (opObjectConstructor) OP(runtime jpl.JPLRuntime, input any, params definiti
 		return nil, err
 	}
 
-	return library.MuxAll(fields, jpl.IOMuxerFunc[*library.ObjectEntry[any], []any](func(entries ...*library.ObjectEntry[any]) ([]any, jpl.JPLError) {
-		return next.Pipe(library.ObjectFromEntries(entries), scope)
+	return library.MuxAll(fields, jpl.IOMuxerFunc[[]*library.ObjectEntry[any], []any](func(entries ...[]*library.ObjectEntry[any]) ([]any, jpl.JPLError) {
+		return next.Pipe(library.ObjectFromEntries(library.MergeSegments(entries)), scope)
+	}))
+}
+
+// Resolve the entries of all objects produced by the specified spread field
+func spreadObjects(runtime jpl.JPLRuntime, input any, field definition.JPLField, scope jpl.JPLRuntimeScope) ([][]*library.ObjectEntry[any], jpl.JPLError) {
+	values, err := runtime.ExecuteInstructions(field.Value, []any{input}, scope, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return library.MuxOne([][]any{values}, jpl.IOMuxerFunc[any, []*library.ObjectEntry[any]](func(args ...any) ([]*library.ObjectEntry[any], jpl.JPLError) {
+		value, err := library.UnwrapValue(args[0])
+		if err != nil {
+			return nil, err
+		}
+		t, err := library.Type(value)
+		if err != nil {
+			return nil, err
+		}
+		if t != jpl.JPLT_OBJECT {
+			return nil, library.ThrowAny(library.NewTypeError("cannot spread %s (%*<100v) into object", string(t), value))
+		}
+		return library.ObjectEntries(value.(map[string]any)), nil
 	}))
 }
 
-// { fields: [{ key: function, value: function, optional: boolean }] }
+// { fields: [{ key: function, value: function, optional: boolean, spread: boolean }] }
 func (opObjectConstructor) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
 	if result.Fields, err = library.MuxOne([][]jpl.JPLField{params.Fields}, jpl.IOMuxerFunc[jpl.JPLField, definition.JPLField](func(args ...jpl.JPLField) (definition.JPLField, jpl.JPLError) {
 		entry := args[0]
-		return definition.JPLField{
-			Key:      call(entry.Key),
+		field := definition.JPLField{
 			Value:    call(entry.Value),
 			Optional: entry.Optional,
-		}, nil
+			Spread:   entry.Spread,
+		}
+		if !entry.Spread {
+			field.Key = call(entry.Key)
+		}
+		return field, nil
 	})); err != nil {
 		return
 	}
@@ -0,0 +1,36 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opRegex struct{}
+
+// { string: string, flags: string, value?: regex }
+func (opRegex) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	// Mapped params already contain the compiled regular expression
+	if params.Value != nil {
+		return next.Pipe(params.Value, scope)
+	}
+	regex, err := library.NewRegex(params.String, params.Flags)
+	if err != nil {
+		return nil, library.ThrowAny(library.NewRuntimeError(err.Error()))
+	}
+	return next.Pipe(regex, scope)
+}
+
+// { string: string, flags: string }
+func (opRegex) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	// The regular expression is compiled once, so that it can be reused for all inputs
+	regex, err2 := library.NewRegex(params.String, params.Flags)
+	if err2 != nil {
+		return result, library.ThrowAny(library.NewRuntimeError(err2.Error()))
+	}
+	return definition.JPLInstructionParams{
+		String: params.String,
+		Flags:  params.Flags,
+		Value:  regex,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package program
+
+import (
+	"github.com/jplorg/jpl/go/definition"
+	"github.com/jplorg/jpl/go/jpl"
+	"github.com/jplorg/jpl/go/library"
+)
+
+type opSpread struct{}
+
+// { pipe: [op] }
+func (opSpread) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	return runtime.ExecuteInstructions(params.Pipe, []any{input}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+		value, err := library.UnwrapValue(output)
+		if err != nil {
+			return nil, err
+		}
+		t, err := library.Type(value)
+		if err != nil {
+			return nil, err
+		}
+		if t != jpl.JPLT_ARRAY {
+			return nil, library.ThrowAny(library.NewTypeError("cannot spread %s (%*<100v) into array", string(t), value))
+		}
+
+		return library.MuxAll([][]any{value.([]any)}, jpl.IOMuxerFunc[any, []any](func(args ...any) ([]any, jpl.JPLError) {
+			return next.Pipe(args[0], scope)
+		}))
+	}))
+}
+
+// { pipe: function }
+func (opSpread) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
+	return definition.JPLInstructionParams{
+		Pipe: call(params.Pipe),
+	}, nil
+}
@@ -1,6 +1,8 @@
 package program
 
 import (
+	"slices"
+
 	"github.com/jplorg/jpl/go/definition"
 	"github.com/jplorg/jpl/go/jpl"
 	"github.com/jplorg/jpl/go/library"
@@ -8,7 +10,7 @@ import (
 
 type opTry struct{}
 
-// { try: [op], catch: [op] }
+// { try: [op], catch: [op] } | { try: [op], catches: [{ kinds: [string], when: [op], raw: boolean, pipe: [op] }] }
 func (opTry) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstructionParams, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
 	nextScope := scope.Next(&jpl.JPLRuntimeScopeConfig{Signal: scope.Signal().Next()})
 	results, err := runtime.ExecuteInstructions(params.Try, []any{input}, nextScope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
@@ -26,6 +28,9 @@ func (opTry) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstruct
 			return nil, err
 		} else {
 			nextScope.Signal().Exit()
+			if params.Catches != nil {
+				return catchError(runtime, executionErr, params.Catches, scope, next)
+			}
 			return runtime.ExecuteInstructions(params.Catch, []any{executionErr.JPLErrorValue()}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
 				return next.Pipe(output, scope)
 			}))
@@ -34,10 +39,63 @@ func (opTry) OP(runtime jpl.JPLRuntime, input any, params definition.JPLInstruct
 	return results, nil
 }
 
-// { try: function, catch: function }
+// { try: function, catch: function } | { try: function, catches: [{ kinds: [string], when: function, raw: boolean, pipe: function }] }
 func (opTry) Map(runtime jpl.JPLRuntime, params jpl.JPLInstructionParams) (result definition.JPLInstructionParams, err jpl.JPLError) {
-	return definition.JPLInstructionParams{
-		Try:   call(params.Try),
-		Catch: call(params.Catch),
-	}, nil
+	result.Try = call(params.Try)
+	if params.Catches != nil {
+		if result.Catches, err = library.MuxOne([][]jpl.JPLCatch{params.Catches}, jpl.IOMuxerFunc[jpl.JPLCatch, definition.JPLCatch](func(args ...jpl.JPLCatch) (definition.JPLCatch, jpl.JPLError) {
+			clause := args[0]
+			result := definition.JPLCatch{Kinds: clause.Kinds, Raw: clause.Raw, Pipe: call(clause.Pipe)}
+			if clause.When != nil {
+				result.When = call(clause.When)
+			}
+			return result, nil
+		})); err != nil {
+			return
+		}
+		return
+	}
+	result.Catch = call(params.Catch)
+	return
+}
+
+// Handle the specified error by the first matching catch clause, which receives the structured error as its input.
+// Raw clauses receive the error value instead, like catch clauses without a head do.
+// If no clause matches, the error is rethrown.
+func catchError(runtime jpl.JPLRuntime, executionErr jpl.JPLExecutionError, catches []definition.JPLCatch, scope jpl.JPLRuntimeScope, next jpl.JPLScopedPiper) ([]any, jpl.JPLError) {
+	info := library.ErrorInfo(executionErr)
+	for _, clause := range catches {
+		if len(clause.Kinds) > 0 && !slices.Contains(clause.Kinds, info["kind"].(string)) {
+			continue
+		}
+		if clause.When != nil {
+			matches, err := runtime.ExecuteInstructions(clause.When, []any{info}, scope, nil)
+			if err != nil {
+				return nil, err
+			}
+			// The clause matches if any of the outputs of its condition is truthy
+			matched := false
+			for _, m := range matches {
+				if matched, err = library.Truthy(m); err != nil {
+					return nil, err
+				}
+				if matched {
+					break
+				}
+			}
+			if !matched {
+				continue
+			}
+		}
+
+		var value any = info
+		if clause.Raw {
+			value = executionErr.JPLErrorValue()
+		}
+		return runtime.ExecuteInstructions(clause.Pipe, []any{value}, scope, jpl.JPLScopedPiperFunc(func(output any, _ jpl.JPLRuntimeScope) ([]any, jpl.JPLError) {
+			return next.Pipe(output, scope)
+		}))
+	}
+
+	return nil, executionErr
 }
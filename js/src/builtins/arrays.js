@@ -0,0 +1,32 @@
+import { JPLTypeError } from '../library';
+
+/** Unwrap the specified value, which must be an array */
+export function unwrapArray(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'array') throw new JPLTypeError('%s (%*<100v) cannot be used as an array', t, value);
+  return value;
+}
+
+/** Unwrap the specified count, which must be an integer of at least `min` */
+export function unwrapCount(runtime, v, min) {
+  const value = runtime.unwrapValue(v ?? null);
+  if (Number.isInteger(value) && value >= min && value <= 2 ** 31 - 1) return value;
+  const t = runtime.type(value);
+  throw new JPLTypeError('%s (%*<100v) cannot be used as a count', t, value);
+}
+
+/**
+ * Unwrap the specified index, which must be an integer.
+ * Negative indices are relative to the end of an array with the specified length.
+ * The result is clamped to the bounds of the array.
+ */
+export function unwrapIndex(runtime, v, length) {
+  const value = runtime.unwrapValue(v ?? null);
+  if (!Number.isInteger(value)) {
+    const t = runtime.type(value);
+    throw new JPLTypeError('%s (%*<100v) cannot be used as an index', t, value);
+  }
+  const i = value < 0 ? value + length : value;
+  return Math.max(0, Math.min(i, length));
+}
@@ -0,0 +1,28 @@
+import { JPLRuntimeError, JPLTypeError } from '../library';
+
+/** Unwrap the specified number, which is to be converted to a string */
+export function unwrapFormattedNumber(runtime, v) {
+  const value = runtime.unwrapValue(v);
+  const t = runtime.type(value);
+  if (t !== 'number') {
+    throw new JPLTypeError('%s (%*<100v) cannot be formatted as a number', t, value);
+  }
+  return value;
+}
+
+/**
+ * Unwrap the specified number of digits, which must be an integer between the specified minimum and 100.
+ * If the number of digits is null, the specified fallback is returned.
+ */
+export function unwrapDigits(runtime, v, min, fallback) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t === 'null') return fallback;
+  if (!Number.isInteger(value)) {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a number of digits', t, value);
+  }
+  if (value < min || value > 100) {
+    throw new JPLRuntimeError('number of digits (%*<100v) must be between %d and 100', value, min);
+  }
+  return value;
+}
@@ -0,0 +1,127 @@
+import { JPLRuntimeError, JPLTypeError, deletePaths, setPath } from '../library';
+import { lookupPath, parsePointer, pointerPath } from './pointer';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = runtime.unwrapValue(arg0 ?? null);
+  const t = runtime.type(value);
+  if (t !== 'array') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a JSON patch', t, value);
+  }
+
+  let result = input;
+  for (let i = 0; i < value.length; i += 1) {
+    signal.checkHealth();
+
+    let message;
+    [result, message] = await applyPatchOperation(runtime, signal, result, value[i]);
+    if (message) {
+      throw new JPLRuntimeError({ message, index: i, operation: value[i] });
+    }
+  }
+  return next(result);
+}
+
+export default builtin;
+
+/**
+ * Apply a single JSON patch operation (RFC 6902) to the specified value.
+ * If the operation fails, a message describing the problem is returned.
+ */
+async function applyPatchOperation(runtime, signal, value, operation) {
+  const fields = runtime.unwrapValue(operation ?? null);
+  if (runtime.type(fields) !== 'object') return [null, 'operation must be an object'];
+  const op = runtime.unwrapValue(fields.op ?? null);
+
+  const [path, message] = patchPointer(runtime, value, fields, 'path');
+  if (message) return [null, message];
+
+  switch (op) {
+    case 'add':
+      if (!Object.hasOwn(fields, 'value')) return [null, 'missing value'];
+      return patchAdd(runtime, signal, value, path, fields.value);
+
+    case 'remove': {
+      const [, found] = lookupPath(runtime, value, path);
+      if (!found) return [null, 'path not found'];
+      return [await deletePaths(runtime, signal, value, [path]), ''];
+    }
+
+    case 'replace': {
+      if (!Object.hasOwn(fields, 'value')) return [null, 'missing value'];
+      const [, found] = lookupPath(runtime, value, path);
+      if (!found) return [null, 'path not found'];
+      return [await setPath(runtime, signal, value, path, fields.value), ''];
+    }
+
+    case 'move': {
+      const [from, fromMessage] = patchPointer(runtime, value, fields, 'from');
+      if (fromMessage) return [null, fromMessage];
+      const [v, found] = lookupPath(runtime, value, from);
+      if (!found) return [null, 'path not found'];
+      if (from.length < path.length && from.every((key, i) => key === path[i])) {
+        return [null, 'cannot move a value into one of its children'];
+      }
+      const result = await deletePaths(runtime, signal, value, [from]);
+      // Resolve the target again, as removing the value may have shifted array indices
+      const [target, targetMessage] = patchPointer(runtime, result, fields, 'path');
+      if (targetMessage) return [null, targetMessage];
+      return patchAdd(runtime, signal, result, target, v);
+    }
+
+    case 'copy': {
+      const [from, fromMessage] = patchPointer(runtime, value, fields, 'from');
+      if (fromMessage) return [null, fromMessage];
+      const [v, found] = lookupPath(runtime, value, from);
+      if (!found) return [null, 'path not found'];
+      return patchAdd(runtime, signal, value, path, v);
+    }
+
+    case 'test': {
+      if (!Object.hasOwn(fields, 'value')) return [null, 'missing value'];
+      const [v, found] = lookupPath(runtime, value, path);
+      if (!found) return [null, 'path not found'];
+      if (!runtime.equals(v, fields.value)) return [null, 'test failed'];
+      return [value, ''];
+    }
+
+    default:
+      return [null, 'unknown operation'];
+  }
+}
+
+/** Resolve the JSON pointer in the specified field of a JSON patch operation */
+function patchPointer(runtime, value, fields, field) {
+  const pointer = runtime.unwrapValue(fields[field] ?? null);
+  if (typeof pointer !== 'string') return [null, `missing ${field}`];
+  const tokens = parsePointer(pointer);
+  if (!tokens) return [null, `invalid JSON pointer in ${field}`];
+  return pointerPath(runtime, value, tokens);
+}
+
+/**
+ * Add the specified value at the specified path.
+ * Array items are inserted, while object fields are replaced.
+ */
+async function patchAdd(runtime, signal, value, path, v) {
+  if (path.length === 0) return [v, ''];
+
+  const parentPath = path.slice(0, -1);
+  const [parent, found] = lookupPath(runtime, value, parentPath);
+  if (!found) return [null, 'path not found'];
+  const p = runtime.unwrapValue(parent);
+
+  switch (runtime.type(p)) {
+    case 'object':
+      return [await setPath(runtime, signal, value, path, v), ''];
+
+    case 'array': {
+      const i = path[path.length - 1];
+      if (i > p.length) return [null, 'array index out of bounds'];
+      const altered = await runtime.alterValue(parent, (a) => a.toSpliced(i, 0, v));
+      return [await setPath(runtime, signal, value, parentPath, altered), ''];
+    }
+
+    default:
+      return [null, 'path not found'];
+  }
+}
@@ -0,0 +1,14 @@
+import { unwrapArray, unwrapCount } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const n = unwrapCount(runtime, arg0, 1);
+
+  const result = [];
+  for (let i = 0; i < value.length; i += n) {
+    result.push(value.slice(i, i + n));
+  }
+  return next(result);
+}
+
+export default builtin;
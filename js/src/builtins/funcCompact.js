@@ -0,0 +1,9 @@
+import { unwrapArray } from './arrays';
+
+async function builtin(runtime, signal, next, input) {
+  const keep = unwrapArray(runtime, input).map((item) => runtime.unwrapValue(item) !== null);
+
+  return next(await runtime.alterValue(input, (value) => value.filter((_, i) => keep[i])));
+}
+
+export default builtin;
@@ -0,0 +1,20 @@
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+import { unwrapKey } from './objects';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const f = unwrapFunction(runtime, arg0);
+
+  const result = {};
+  for (const item of value) {
+    const results = await callFunction(runtime, signal, f, item);
+    results.forEach((r) => {
+      const key = unwrapKey(runtime, r);
+      result[key] = (Object.hasOwn(result, key) ? result[key] : 0) + 1;
+    });
+  }
+  return next(result);
+}
+
+export default builtin;
@@ -0,0 +1,56 @@
+import { JPLRuntimeError, applyArray, applyObject } from '../library';
+
+async function builtin(runtime, signal, next, input, arg0, arg1) {
+  const strategy = runtime.unwrapValue(arg1 ?? null) ?? 'replace';
+  if (!['replace', 'concat', 'index'].includes(strategy)) {
+    throw new JPLRuntimeError(
+      'invalid array strategy (%*<100v), expected "replace", "concat" or "index"',
+      strategy,
+    );
+  }
+
+  return next(await deepMerge(runtime, signal, input, arg0 ?? null, strategy));
+}
+
+export default builtin;
+
+/**
+ * Merge value `b` into value `a` recursively.
+ *
+ * Objects are merged by key, while arrays are merged according to the specified strategy:
+ * `replace` uses `b`, `concat` appends all items of `b` to `a` and `index` merges items at the same index.
+ * All other values are replaced by `b`.
+ */
+async function deepMerge(runtime, signal, a, b, strategy) {
+  signal.checkHealth();
+
+  const ua = runtime.unwrapValue(a);
+  const ub = runtime.unwrapValue(b);
+  const ta = runtime.type(ua);
+  const tb = runtime.type(ub);
+
+  if (ta === 'object' && tb === 'object') {
+    const changes = [];
+    for (const key of Object.keys(ub).sort()) {
+      const value = Object.hasOwn(ua, key)
+        ? await deepMerge(runtime, signal, ua[key], ub[key], strategy)
+        : ub[key];
+      changes.push([key, value]);
+    }
+    return runtime.alterValue(a, (v) => applyObject(v, changes));
+  }
+
+  if (ta === 'array' && tb === 'array' && strategy !== 'replace') {
+    if (strategy === 'concat') return runtime.alterValue(a, (v) => [...v, ...ub]);
+
+    const changes = [];
+    for (let i = 0; i < ub.length; i += 1) {
+      const value =
+        i < ua.length ? await deepMerge(runtime, signal, ua[i], ub[i], strategy) : ub[i];
+      changes.push([i, value]);
+    }
+    return runtime.alterValue(a, (v) => applyArray(v, changes));
+  }
+
+  return b;
+}
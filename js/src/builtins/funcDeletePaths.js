@@ -0,0 +1,10 @@
+import { deletePaths } from '../library';
+import { unwrapPaths } from './paths';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const paths = unwrapPaths(runtime, arg0);
+
+  return next(await deletePaths(runtime, signal, input, paths));
+}
+
+export default builtin;
@@ -0,0 +1,56 @@
+import { formatPointer } from './pointer';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  return next(diffPatch(runtime, signal, input, arg0 ?? null, [], []));
+}
+
+export default builtin;
+
+/**
+ * Append the JSON patch operations (RFC 6902) that transform value `a` at the specified path into value `b`.
+ *
+ * Object fields are compared by key and array items are compared by index.
+ */
+function diffPatch(runtime, signal, a, b, path, operations) {
+  signal.checkHealth();
+
+  if (runtime.equals(a, b)) return operations;
+
+  const va = runtime.unwrapValue(a);
+  const vb = runtime.unwrapValue(b);
+  const ta = runtime.type(va);
+  const tb = runtime.type(vb);
+
+  if (ta === 'object' && tb === 'object') {
+    const keys = [...new Set([...Object.keys(va), ...Object.keys(vb)])].sort();
+    for (const key of keys) {
+      const p = [...path, key];
+      if (!Object.hasOwn(vb, key)) {
+        operations.push({ op: 'remove', path: formatPointer(p) });
+      } else if (!Object.hasOwn(va, key)) {
+        operations.push({ op: 'add', path: formatPointer(p), value: vb[key] });
+      } else {
+        diffPatch(runtime, signal, va[key], vb[key], p, operations);
+      }
+    }
+    return operations;
+  }
+
+  if (ta === 'array' && tb === 'array') {
+    const n = Math.min(va.length, vb.length);
+    for (let i = 0; i < n; i += 1) {
+      diffPatch(runtime, signal, va[i], vb[i], [...path, i], operations);
+    }
+    for (let i = n; i < vb.length; i += 1) {
+      operations.push({ op: 'add', path: formatPointer([...path, i]), value: vb[i] });
+    }
+    // Remove surplus items from the end, so that the indices of the remaining items do not shift
+    for (let i = va.length - 1; i >= n; i -= 1) {
+      operations.push({ op: 'remove', path: formatPointer([...path, i]) });
+    }
+    return operations;
+  }
+
+  operations.push({ op: 'replace', path: formatPointer(path), value: b });
+  return operations;
+}
@@ -1,7 +1,7 @@
-import { JPLRuntimeError } from '../library';
+import { JPLUserError } from '../library';
 
 function builtin(runtime, signal, next, input) {
-  throw new JPLRuntimeError(input);
+  throw new JPLUserError(input);
 }
 
 export default builtin;
@@ -0,0 +1,18 @@
+import { applyObject } from '../library';
+import { callFunction, unwrapFunction } from './functions';
+import { unwrapObject } from './objects';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const f = unwrapFunction(runtime, arg0);
+  const value = unwrapObject(runtime, input);
+
+  const changes = [];
+  for (const key of Object.keys(value).sort()) {
+    const results = await callFunction(runtime, signal, f, key, value[key]);
+    if (!results.some((result) => runtime.truthy(result))) changes.push([key]);
+  }
+
+  return next(await runtime.alterValue(input, (v) => applyObject(v, changes)));
+}
+
+export default builtin;
@@ -0,0 +1,28 @@
+import { unwrapArray, unwrapCount } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const depth = arg0 != null ? unwrapCount(runtime, arg0, 0) : -1;
+
+  return next(flatten(runtime, signal, value, depth, []));
+}
+
+export default builtin;
+
+/**
+ * Append all items of the specified array to `result`, flattening nested arrays up to the specified depth.
+ * A negative depth flattens all nested arrays.
+ */
+function flatten(runtime, signal, value, depth, result) {
+  signal.checkHealth();
+
+  for (const item of value) {
+    const v = depth !== 0 ? runtime.unwrapValue(item) : null;
+    if (Array.isArray(v)) {
+      flatten(runtime, signal, v, depth - 1, result);
+    } else {
+      result.push(item);
+    }
+  }
+  return result;
+}
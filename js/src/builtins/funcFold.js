@@ -0,0 +1,11 @@
+import { foldGenerator, unwrapFunction } from './functions';
+
+async function builtin(runtime, signal, next, input, arg0, arg1, arg2) {
+  const gen = unwrapFunction(runtime, arg0);
+  const f = unwrapFunction(runtime, arg1);
+
+  const { acc } = await foldGenerator(runtime, signal, gen, f, input, arg2 ?? null, () => []);
+  return next(acc);
+}
+
+export default builtin;
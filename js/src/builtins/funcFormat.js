@@ -0,0 +1,11 @@
+import { JPLTypeError, formatTemplate } from '../library';
+
+async function builtin(runtime, signal, next, input, arg0, ...replacements) {
+  const tmpl = runtime.unwrapValue(arg0 ?? null);
+  const t = runtime.type(tmpl);
+  if (t !== 'string') throw new JPLTypeError('%s (%*<100v) cannot be used as a template', t, tmpl);
+
+  return next(formatTemplate(tmpl, ...replacements));
+}
+
+export default builtin;
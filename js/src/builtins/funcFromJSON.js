@@ -1,24 +1,37 @@
-import { JPLRuntimeError, JPLTypeError } from '../library';
+import { JPLTypeError } from '../library';
+import { JSONParser } from './json';
 
-function builtin(runtime, signal, next, input) {
+async function builtin(runtime, signal, next, input, arg0) {
   const value = runtime.unwrapValue(input);
   const t = runtime.type(value);
+  if (t !== 'string') throw new JPLTypeError('%s (%*<100v) cannot be parsed as JSON', t, value);
 
-  switch (t) {
-    case 'string': {
-      let result;
-      try {
-        result = JSON.parse(value);
-      } catch (err) {
-        throw new JPLRuntimeError(err.message);
-      }
-      return next(result);
-    }
+  const { multiple, json5 } = unwrapJSONParseOptions(runtime, arg0);
 
-    default:
+  const parser = new JSONParser(signal, value, json5);
+  let results;
+  try {
+    results = parser.parseDocuments(multiple);
+  } catch (err) {
+    throw parser.error(err);
   }
-
-  throw new JPLTypeError('%s (%*<100v) cannot be parsed as JSON', t, value);
+  return runtime.muxAll([results], next);
 }
 
 export default builtin;
+
+/**
+ * Unwrap the specified JSON parse options, which must be an object or null.
+ * The results specify whether multiple documents and JSON5 syntax are accepted.
+ */
+function unwrapJSONParseOptions(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t === 'null') return { multiple: false, json5: false };
+  if (t !== 'object') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as JSON options', t, value);
+  }
+
+  const flag = (key) => runtime.unwrapValue(value[key] ?? null) === true;
+  return { multiple: flag('multiple'), json5: flag('json5') };
+}
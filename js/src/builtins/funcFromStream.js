@@ -0,0 +1,33 @@
+import { JPLTypeError, setPath } from '../library';
+import { callFunction, unwrapFunction } from './functions';
+import { unwrapPath } from './paths';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const f = unwrapFunction(runtime, arg0);
+  const events = await callFunction(runtime, signal, f, input);
+
+  const results = [];
+  let current = null;
+  for (const event of events) {
+    const [path, leaf, hasLeaf] = unwrapStreamEvent(runtime, event);
+    if (hasLeaf) current = await setPath(runtime, signal, current, path, leaf);
+    // A value is complete if it is a top-level leaf or if its last top-level child has been closed
+    if ((hasLeaf && path.length === 0) || (!hasLeaf && path.length === 1)) {
+      results.push(current);
+      current = null;
+    }
+  }
+  return runtime.muxAll([results], next);
+}
+
+export default builtin;
+
+/** Unwrap the specified stream event, which must be either `[path, leaf]` or `[path]` */
+function unwrapStreamEvent(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  if (!Array.isArray(value) || (value.length !== 1 && value.length !== 2)) {
+    const t = runtime.type(value);
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a stream event', t, value);
+  }
+  return [unwrapPath(runtime, value[0]), value[1] ?? null, value.length === 2];
+}
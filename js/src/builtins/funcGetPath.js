@@ -0,0 +1,10 @@
+import { getPath } from '../library';
+import { unwrapPath } from './paths';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const path = unwrapPath(runtime, arg0);
+
+  return next(await getPath(runtime, signal, input, path));
+}
+
+export default builtin;
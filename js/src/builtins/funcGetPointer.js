@@ -0,0 +1,10 @@
+import { getPath } from '../library';
+import { resolvePointer } from './pointer';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const path = resolvePointer(runtime, input, arg0);
+
+  return next(await getPath(runtime, signal, input, path));
+}
+
+export default builtin;
@@ -0,0 +1,21 @@
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+import { unwrapKey } from './objects';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const f = unwrapFunction(runtime, arg0);
+
+  const result = {};
+  for (const item of value) {
+    const results = await callFunction(runtime, signal, f, item);
+    results.forEach((r) => {
+      const key = unwrapKey(runtime, r);
+      if (!Object.hasOwn(result, key)) result[key] = [];
+      result[key].push(item);
+    });
+  }
+  return next(result);
+}
+
+export default builtin;
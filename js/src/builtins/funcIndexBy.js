@@ -0,0 +1,19 @@
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+import { unwrapKey } from './objects';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const f = unwrapFunction(runtime, arg0);
+
+  const result = {};
+  for (const item of value) {
+    const results = await callFunction(runtime, signal, f, item);
+    results.forEach((r) => {
+      result[unwrapKey(runtime, r)] = item;
+    });
+  }
+  return next(result);
+}
+
+export default builtin;
@@ -0,0 +1,10 @@
+import { unwrapArray } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+
+  const i = value.findIndex((item) => runtime.equals(item, arg0 ?? null));
+  return next(i >= 0 ? i : null);
+}
+
+export default builtin;
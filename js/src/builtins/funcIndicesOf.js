@@ -0,0 +1,13 @@
+import { unwrapArray } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+
+  const result = [];
+  value.forEach((item, i) => {
+    if (runtime.equals(item, arg0 ?? null)) result.push(i);
+  });
+  return next(result);
+}
+
+export default builtin;
@@ -0,0 +1,10 @@
+import { unwrapArray, unwrapIndex } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0, arg1) {
+  const value = unwrapArray(runtime, input);
+  const i = unwrapIndex(runtime, arg0, value.length);
+
+  return next(await runtime.alterValue(input, (v) => v.toSpliced(i, 0, arg1 ?? null)));
+}
+
+export default builtin;
@@ -0,0 +1,13 @@
+import { replaceEntries, unwrapKey, unwrapObject } from './objects';
+
+async function builtin(runtime, signal, next, input) {
+  const value = unwrapObject(runtime, input);
+
+  const entries = Object.keys(value)
+    .sort()
+    .map((key) => [unwrapKey(runtime, value[key]), key]);
+
+  return next(await replaceEntries(runtime, input, entries));
+}
+
+export default builtin;
@@ -0,0 +1,45 @@
+import { JPLRuntimeError } from '../library';
+import { unwrapArray } from './arrays';
+import { unwrapFunction } from './functions';
+import { hashKeys } from './sets';
+
+async function builtin(runtime, signal, next, input, arg0, arg1, arg2, arg3) {
+  const left = unwrapArray(runtime, input);
+  const right = unwrapArray(runtime, arg0);
+  const leftKey = unwrapFunction(runtime, arg1);
+  const rightKey = arg2 != null ? unwrapFunction(runtime, arg2) : leftKey;
+  const mode = runtime.unwrapValue(arg3 ?? null) ?? 'inner';
+  if (!['inner', 'left', 'full'].includes(mode)) {
+    throw new JPLRuntimeError(
+      'invalid join mode (%*<100v), expected "inner", "left" or "full"',
+      mode,
+    );
+  }
+
+  const kl = await hashKeys(runtime, signal, left, leftKey);
+  const kr = await hashKeys(runtime, signal, right, rightKey);
+
+  const index = new Map();
+  kr.forEach((key, i) => {
+    if (!index.has(key)) index.set(key, []);
+    index.get(key).push(i);
+  });
+  const matched = new Array(right.length).fill(false);
+  const result = [];
+  left.forEach((item, i) => {
+    const matches = index.get(kl[i]) ?? [];
+    matches.forEach((j) => {
+      matched[j] = true;
+      result.push([item, right[j]]);
+    });
+    if (matches.length === 0 && mode !== 'inner') result.push([item, null]);
+  });
+  if (mode === 'full') {
+    right.forEach((item, j) => {
+      if (!matched[j]) result.push([null, item]);
+    });
+  }
+  return next(result);
+}
+
+export default builtin;
@@ -0,0 +1,19 @@
+import { callFunction, unwrapFunction } from './functions';
+import { replaceEntries, unwrapKey, unwrapObject } from './objects';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const f = unwrapFunction(runtime, arg0);
+  const value = unwrapObject(runtime, input);
+
+  const entries = [];
+  for (const key of Object.keys(value).sort()) {
+    const results = await callFunction(runtime, signal, f, key, value[key]);
+    results.forEach((result) => {
+      entries.push([unwrapKey(runtime, result), value[key]]);
+    });
+  }
+
+  return next(await replaceEntries(runtime, input, entries));
+}
+
+export default builtin;
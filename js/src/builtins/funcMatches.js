@@ -0,0 +1,11 @@
+import { findMatches } from '../library';
+import { unwrapRegex, unwrapSubject } from './regex';
+
+function builtin(runtime, signal, next, input, arg0) {
+  const regex = unwrapRegex(runtime, arg0);
+  const value = unwrapSubject(runtime, input);
+
+  return runtime.muxAll([findMatches(regex, value, regex.global)], next);
+}
+
+export default builtin;
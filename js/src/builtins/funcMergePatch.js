@@ -0,0 +1,34 @@
+import { applyObject } from '../library';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  return next(await mergePatch(runtime, signal, input, arg0 ?? null));
+}
+
+export default builtin;
+
+/** Apply the specified JSON merge patch (RFC 7386) to the specified value */
+async function mergePatch(runtime, signal, value, patch) {
+  signal.checkHealth();
+
+  const fields = runtime.unwrapValue(patch);
+  if (runtime.type(fields) !== 'object') return patch;
+
+  let source = value;
+  let target = runtime.unwrapValue(value);
+  if (runtime.type(target) !== 'object') {
+    target = {};
+    source = target;
+  }
+
+  const changes = [];
+  for (const [key, field] of Object.entries(fields)) {
+    if (runtime.unwrapValue(field) === null) {
+      changes.push([key]);
+      continue;
+    }
+    const current = Object.hasOwn(target, key) ? target[key] : null;
+    changes.push([key, await mergePatch(runtime, signal, current, field)]);
+  }
+
+  return runtime.alterValue(source, (v) => applyObject(v, changes));
+}
@@ -0,0 +1,72 @@
+import { JPLRuntimeError, JPLTypeConversionError, JPLTypeError, compileRegex } from '../library';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = runtime.unwrapValue(input);
+  const t = runtime.type(value);
+  if (t !== 'string') throw new JPLTypeError('%s (%*<100v) cannot be parsed as a number', t, value);
+  const [thousands, decimal] = unwrapNumberSeparators(runtime, arg0);
+
+  let pattern = '^([+-]?)([0-9]*)';
+  if (thousands !== '') {
+    pattern = `^([+-]?)([0-9]{1,3}(?:${escapeRegExp(thousands)}[0-9]{3})+|[0-9]*)`;
+  }
+  pattern += `(?:${escapeRegExp(decimal)}([0-9]*))?(?:[eE]([+-]?[0-9]+))?$`;
+  // Patterns are cached, as most calls use the same separators
+  const [m] = value.matchAll(compileRegex(pattern, '').regexp);
+  if (!m || (m[2] === '' && (m[3] ?? '') === '')) {
+    throw new JPLTypeConversionError('%s (%*<100v) does not contain a valid number', t, value);
+  }
+  let normalized = `${m[1]}0${m[2].split(thousands).join('')}.${m[3] ?? ''}0`;
+  if (m[4] !== undefined) normalized += `e${m[4]}`;
+  // Adding zero normalizes negative zero
+  const result = runtime.normalizeValue(Number(normalized) + 0);
+  if (!Number.isFinite(result)) {
+    throw new JPLTypeConversionError('%s (%*<100v) does not contain a valid number', t, value);
+  }
+  return next(result);
+}
+
+export default builtin;
+
+function escapeRegExp(s) {
+  return s.replace(/[.*+?^${}()|[\]\\/-]/g, '\\$&');
+}
+
+/**
+ * Unwrap the thousands separator and the decimal separator from the specified options,
+ * which must be an object or null.
+ * By default, numbers are formatted like `1,234.5`. An empty thousands separator disallows grouping digits.
+ */
+function unwrapNumberSeparators(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t === 'null') return [',', '.'];
+  if (t !== 'object') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as number options', t, value);
+  }
+
+  const unwrapSeparator = (key, fallback) => {
+    const s = runtime.unwrapValue(value[key] ?? null);
+    const st = runtime.type(s);
+    if (st === 'null') return fallback;
+    if (st === 'string') return s;
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a separator', st, s);
+  };
+  const thousands = unwrapSeparator('thousandsSeparator', ',');
+  const decimal = unwrapSeparator('decimalSeparator', '.');
+
+  // Separators must not be ambiguous
+  if (
+    decimal === '' ||
+    /[0-9+\-eE]/.test(thousands + decimal) ||
+    thousands.includes(decimal) ||
+    (thousands !== '' && decimal.includes(thousands))
+  ) {
+    throw new JPLRuntimeError(
+      'invalid number separators (%*<100v) and (%*<100v)',
+      thousands,
+      decimal,
+    );
+  }
+  return [thousands, decimal];
+}
@@ -0,0 +1,18 @@
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const f = unwrapFunction(runtime, arg0);
+
+  const matching = [];
+  const nonMatching = [];
+  for (const item of value) {
+    const results = await callFunction(runtime, signal, f, item);
+    if (results.some((result) => runtime.truthy(result))) matching.push(item);
+    else nonMatching.push(item);
+  }
+  return next([matching, nonMatching]);
+}
+
+export default builtin;
@@ -0,0 +1,21 @@
+import { callFunction, unwrapFunction } from './functions';
+import { walkPaths } from './paths';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const filter = arg0 != null ? unwrapFunction(runtime, arg0) : null;
+
+  const paths = [];
+  await walkPaths(runtime, signal, input, [], async (path, value) => {
+    if (!filter) {
+      paths.push(path);
+      return;
+    }
+    const results = await callFunction(runtime, signal, filter, value);
+    results.forEach((result) => {
+      if (runtime.truthy(result)) paths.push(path);
+    });
+  });
+  return runtime.muxAll([paths], next);
+}
+
+export default builtin;
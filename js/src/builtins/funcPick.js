@@ -0,0 +1,15 @@
+import { getPath, setPath } from '../library';
+import { unwrapKeyPaths } from './paths';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const paths = unwrapKeyPaths(runtime, arg0);
+
+  let result = null;
+  for (const path of paths) {
+    const v = await getPath(runtime, signal, input, path);
+    result = await setPath(runtime, signal, result, path, v);
+  }
+  return next(result);
+}
+
+export default builtin;
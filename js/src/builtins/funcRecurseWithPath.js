@@ -0,0 +1,11 @@
+import { walkPaths } from './paths';
+
+async function builtin(runtime, signal, next, input) {
+  const entries = [[[], input]];
+  await walkPaths(runtime, signal, input, [], (path, value) => {
+    entries.push([path, value]);
+  });
+  return runtime.muxAll([entries], next);
+}
+
+export default builtin;
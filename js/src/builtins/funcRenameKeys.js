@@ -0,0 +1,25 @@
+import { JPLTypeError, applyObject } from '../library';
+import { unwrapKey, unwrapObject } from './objects';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const mapping = runtime.unwrapValue(arg0 ?? null);
+  const t = runtime.type(mapping);
+  if (t !== 'object') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a mapping of keys', t, mapping);
+  }
+  const value = unwrapObject(runtime, input);
+
+  // Remove all renamed fields first, so that fields can be swapped and renamed fields take precedence
+  const removals = [];
+  const renames = [];
+  for (const key of Object.keys(mapping).sort()) {
+    if (!Object.hasOwn(value, key)) continue;
+    const k = unwrapKey(runtime, mapping[key]);
+    removals.push([key]);
+    renames.push([k, value[key]]);
+  }
+
+  return next(await runtime.alterValue(input, (v) => applyObject(v, [...removals, ...renames])));
+}
+
+export default builtin;
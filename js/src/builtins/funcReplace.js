@@ -0,0 +1,48 @@
+import { JPLTypeError, applyCombinations, findMatches } from '../library';
+import { callFunction } from './functions';
+import { unwrapRegex, unwrapSubject } from './regex';
+
+async function builtin(runtime, signal, next, input, arg0, arg1) {
+  const regex = unwrapRegex(runtime, arg0);
+  const value = unwrapSubject(runtime, input);
+  const replacement = runtime.unwrapValue(arg1 ?? null);
+  const tr = runtime.type(replacement);
+  if (!['string', 'function'].includes(tr)) {
+    throw new JPLTypeError('cannot use %s (%*<100v) as replacement', tr, replacement);
+  }
+
+  // Every match is replaced by each of its replacements, which results in all combinations of replacements
+  const matches = findMatches(regex, value, regex.global);
+  const replacements = [];
+  for (const match of matches) {
+    if (tr === 'string') {
+      replacements.push([replacement]);
+    } else {
+      const results = await callFunction(runtime, signal, replacement, match);
+      replacements.push(
+        results.map((result) => {
+          const r = runtime.unwrapValue(result);
+          const t = runtime.type(r);
+          if (t !== 'string') {
+            throw new JPLTypeError('cannot use %s (%*<100v) as replacement', t, r);
+          }
+          return r;
+        }),
+      );
+    }
+  }
+
+  const chars = [...value];
+  const items = matches.map((match) => match.string);
+  return runtime.muxAll([applyCombinations(items, replacements)], (results) => {
+    let result = '';
+    let from = 0;
+    matches.forEach((match, i) => {
+      result += chars.slice(from, match.offset).join('') + results[i];
+      from = match.offset + match.length;
+    });
+    return next(result + chars.slice(from).join(''));
+  });
+}
+
+export default builtin;
@@ -0,0 +1,12 @@
+import { JPLTypeError, errorFromInfo } from '../library';
+
+function builtin(runtime, signal, next, input) {
+  const err = errorFromInfo(input);
+  if (err) throw err;
+
+  const value = runtime.unwrapValue(input);
+  const t = runtime.type(value);
+  throw new JPLTypeError('cannot rethrow %s (%*<100v) as it does not describe an error', t, value);
+}
+
+export default builtin;
@@ -0,0 +1,10 @@
+import { setPath } from '../library';
+import { unwrapPath } from './paths';
+
+async function builtin(runtime, signal, next, input, arg0, arg1) {
+  const path = unwrapPath(runtime, arg0);
+
+  return next(await setPath(runtime, signal, input, path, arg1 ?? null));
+}
+
+export default builtin;
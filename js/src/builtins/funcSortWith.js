@@ -0,0 +1,29 @@
+import { JPLTypeError } from '../library';
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+import { sortStable } from './sort';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const cmp = unwrapFunction(runtime, arg0);
+
+  return next(
+    await sortStable(value, async (a, b) => {
+      signal.checkHealth();
+      const [result] = await callFunction(runtime, signal, cmp, null, a, b);
+      return unwrapComparison(runtime, result);
+    }),
+  );
+}
+
+export default builtin;
+
+/** Unwrap the specified comparison result, which must be a number */
+function unwrapComparison(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'number') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a comparison result', t, value);
+  }
+  return Math.sign(value);
+}
@@ -0,0 +1,45 @@
+import { JPLTypeError, findMatches } from '../library';
+import { unwrapRegex } from './regex';
+
+function builtin(runtime, signal, next, input, arg0) {
+  const value = runtime.unwrapValue(input);
+  const t = runtime.type(value);
+  const separator = runtime.unwrapValue(arg0 ?? null);
+  const ts = runtime.type(separator);
+
+  if (t === 'string') {
+    switch (ts) {
+      case 'string':
+        if (separator === '') return next([...value]);
+        return next(value.split(separator));
+
+      case 'object': {
+        const regex = unwrapRegex(runtime, arg0);
+
+        // Empty matches are ignored
+        const chars = [...value];
+        const parts = [];
+        let from = 0;
+        findMatches(regex, value, true).forEach((match) => {
+          if (match.length === 0) return;
+          parts.push(chars.slice(from, match.offset).join(''));
+          from = match.offset + match.length;
+        });
+        parts.push(chars.slice(from).join(''));
+        return next(parts);
+      }
+
+      default:
+    }
+  }
+
+  throw new JPLTypeError(
+    '%s (%*<100v) cannot be split by %s (%*<100v)',
+    t,
+    value,
+    ts,
+    separator,
+  );
+}
+
+export default builtin;
@@ -0,0 +1,19 @@
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+import { unwrapNumber } from './math';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = unwrapArray(runtime, input);
+  const f = unwrapFunction(runtime, arg0);
+
+  let result = 0;
+  for (const item of value) {
+    const results = await callFunction(runtime, signal, f, item);
+    results.forEach((r) => {
+      result += unwrapNumber(runtime, r);
+    });
+  }
+  return next(result);
+}
+
+export default builtin;
@@ -0,0 +1,10 @@
+import { unwrapArray, unwrapCount } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  unwrapArray(runtime, input);
+  const n = unwrapCount(runtime, arg0, 0);
+
+  return next(await runtime.alterValue(input, (value) => value.slice(0, n)));
+}
+
+export default builtin;
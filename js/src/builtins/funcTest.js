@@ -0,0 +1,11 @@
+import { findMatches } from '../library';
+import { unwrapRegex, unwrapSubject } from './regex';
+
+function builtin(runtime, signal, next, input, arg0) {
+  const regex = unwrapRegex(runtime, arg0);
+  const value = unwrapSubject(runtime, input);
+
+  return next(findMatches(regex, value, false).length > 0);
+}
+
+export default builtin;
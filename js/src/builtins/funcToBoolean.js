@@ -0,0 +1,28 @@
+import { JPLTypeConversionError, JPLTypeError } from '../library';
+
+async function builtin(runtime, signal, next, input) {
+  const value = runtime.unwrapValue(input);
+  const t = runtime.type(value);
+
+  switch (t) {
+    case 'null':
+      return next(false);
+
+    case 'boolean':
+      return next(value);
+
+    case 'number':
+      return next(value !== 0);
+
+    case 'string':
+      if (value === 'true') return next(true);
+      if (value === 'false') return next(false);
+      throw new JPLTypeConversionError('%s (%*<100v) does not contain a valid boolean', t, value);
+
+    default:
+  }
+
+  throw new JPLTypeError('%s (%*<100v) cannot be converted to a boolean', t, value);
+}
+
+export default builtin;
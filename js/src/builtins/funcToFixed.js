@@ -0,0 +1,10 @@
+import { unwrapDigits, unwrapFormattedNumber } from './conversion';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const n = unwrapFormattedNumber(runtime, input);
+  const digits = unwrapDigits(runtime, arg0, 0, 0);
+
+  return next(n.toFixed(digits));
+}
+
+export default builtin;
@@ -0,0 +1,52 @@
+import { JPLRuntimeError, JPLTypeConversionError, JPLTypeError } from '../library';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const value = runtime.unwrapValue(input);
+  const t = runtime.type(value);
+  const radix = unwrapRadix(runtime, arg0);
+
+  switch (t) {
+    case 'number':
+      // Adding zero normalizes negative zero
+      return next(Math.trunc(value) + 0);
+
+    case 'string': {
+      let s = value;
+      let sign = 1;
+      if (s.startsWith('-')) {
+        sign = -1;
+        s = s.slice(1);
+      } else if (s.startsWith('+')) {
+        s = s.slice(1);
+      }
+      let n = 0n;
+      for (const c of s.toLowerCase()) {
+        const digit = parseInt(c, 36);
+        if (!(digit < radix)) {
+          n = null;
+          break;
+        }
+        n = n * BigInt(radix) + BigInt(digit);
+      }
+      const result = n !== null && s !== '' ? Number(n) : NaN;
+      if (!Number.isFinite(result)) {
+        throw new JPLTypeConversionError('%s (%*<100v) does not contain a valid integer', t, value);
+      }
+      return next(sign * result + 0);
+    }
+
+    default:
+  }
+
+  throw new JPLTypeError('%s (%*<100v) cannot be converted to an integer', t, value);
+}
+
+export default builtin;
+
+/** Unwrap the specified radix, which must be an integer between 2 and 36, and defaults to 10 */
+function unwrapRadix(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  if (value === null) return 10;
+  if (Number.isInteger(value) && value >= 2 && value <= 36) return value;
+  throw new JPLRuntimeError('invalid radix (%*<100v), expected an integer between 2 and 36', value);
+}
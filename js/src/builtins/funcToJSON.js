@@ -1,5 +1,34 @@
-function builtin(runtime, signal, next, input) {
-  return next(runtime.stringifyJSON(input, false));
+import { JPLTypeError, formatJSON } from '../library';
+import { unwrapCount } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const options = unwrapJSONOptions(runtime, arg0);
+
+  return next(formatJSON(input, options));
 }
 
 export default builtin;
+
+/** Unwrap the specified JSON options, which must be an object or null */
+function unwrapJSONOptions(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t === 'null') return {};
+  if (t !== 'object') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as JSON options', t, value);
+  }
+
+  // Like `JSON.stringify`, indentation is limited to 10 characters
+  const indent = runtime.unwrapValue(value.indent ?? null);
+  let gap = '';
+  if (typeof indent === 'string') gap = [...indent].slice(0, 10).join('');
+  else if (indent !== null) gap = ' '.repeat(Math.min(unwrapCount(runtime, indent, 0), 10));
+
+  const flag = (key) => runtime.unwrapValue(value[key] ?? null) === true;
+  return {
+    indent: gap,
+    sortKeys: flag('sortKeys'),
+    asciiOnly: flag('asciiOnly'),
+    canonical: flag('canonical'),
+  };
+}
@@ -0,0 +1,10 @@
+import { unwrapDigits, unwrapFormattedNumber } from './conversion';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const n = unwrapFormattedNumber(runtime, input);
+  const digits = unwrapDigits(runtime, arg0, 1, -1);
+
+  return next(digits < 0 ? n.toPrecision() : n.toPrecision(digits));
+}
+
+export default builtin;
@@ -0,0 +1,40 @@
+async function builtin(runtime, signal, next, input) {
+  const events = [];
+  streamEvents(runtime, signal, input, [], events);
+  return runtime.muxAll([events], next);
+}
+
+export default builtin;
+
+/**
+ * Append the stream events of the specified value.
+ *
+ * Leaves, which are scalars or empty arrays and objects, produce an event `[path, leaf]`,
+ * while closing a non-empty array or object produces an event `[path]` with the path of its last child.
+ */
+function streamEvents(runtime, signal, value, path, events) {
+  signal.checkHealth();
+
+  const v = runtime.unwrapValue(value);
+  let keys = [];
+  switch (runtime.type(v)) {
+    case 'array':
+      keys = v.map((_, i) => i);
+      break;
+
+    case 'object':
+      keys = Object.keys(v).sort();
+      break;
+
+    default:
+  }
+
+  if (keys.length === 0) {
+    events.push([path, value]);
+    return;
+  }
+  keys.forEach((key) => {
+    streamEvents(runtime, signal, v[key], [...path, key], events);
+  });
+  events.push([[...path, keys[keys.length - 1]]]);
+}
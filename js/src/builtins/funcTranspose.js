@@ -0,0 +1,14 @@
+import { unwrapArray } from './arrays';
+
+async function builtin(runtime, signal, next, input) {
+  const rows = unwrapArray(runtime, input).map((row) => unwrapArray(runtime, row));
+
+  // Shorter rows are padded with null
+  const l = Math.max(0, ...rows.map((row) => row.length));
+  const result = Array.from({ length: l }, (_, j) =>
+    rows.map((row) => (j < row.length ? row[j] : null)),
+  );
+  return next(result);
+}
+
+export default builtin;
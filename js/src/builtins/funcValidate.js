@@ -0,0 +1,11 @@
+import { SchemaValidator } from './schema';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const schema = runtime.stripJSON(arg0 ?? null);
+  const instance = runtime.stripJSON(input);
+
+  const validator = new SchemaValidator(runtime, signal, schema);
+  return next(validator.validate(instance, schema, [], []).errors);
+}
+
+export default builtin;
@@ -0,0 +1,48 @@
+import { callFunction, unwrapFunction } from './functions';
+
+async function builtin(runtime, signal, next, input, arg0) {
+  const f = unwrapFunction(runtime, arg0);
+
+  const results = await walkValue(runtime, signal, f, input);
+  return runtime.muxAll([results], next);
+}
+
+export default builtin;
+
+/**
+ * Rebuild the specified value by applying `f` to all of its nodes from the leaves up.
+ * Arrays and objects are rebuilt by altering the original value, so that custom types can take part in the rebuild.
+ *
+ * All outputs of `f` on array items are included in the resulting array, while object fields use the first output of `f`
+ * and are removed if there is none.
+ */
+async function walkValue(runtime, signal, f, value) {
+  signal.checkHealth();
+
+  const v = runtime.unwrapValue(value);
+  let node = value;
+  switch (runtime.type(v)) {
+    case 'array': {
+      const items = [];
+      for (const item of v) {
+        items.push(...(await walkValue(runtime, signal, f, item)));
+      }
+      node = await runtime.alterValue(value, () => items);
+      break;
+    }
+
+    case 'object': {
+      const fields = {};
+      for (const key of Object.keys(v).sort()) {
+        const results = await walkValue(runtime, signal, f, v[key]);
+        if (results.length > 0) fields[key] = results[0];
+      }
+      node = await runtime.alterValue(value, () => fields);
+      break;
+    }
+
+    default:
+  }
+
+  return callFunction(runtime, signal, f, node);
+}
@@ -0,0 +1,15 @@
+import { unwrapArray, unwrapCount } from './arrays';
+
+async function builtin(runtime, signal, next, input, arg0, arg1) {
+  const value = unwrapArray(runtime, input);
+  const n = unwrapCount(runtime, arg0, 1);
+  const step = arg1 != null ? unwrapCount(runtime, arg1, 1) : 1;
+
+  const result = [];
+  for (let i = 0; i + n <= value.length; i += step) {
+    result.push(value.slice(i, i + n));
+  }
+  return next(result);
+}
+
+export default builtin;
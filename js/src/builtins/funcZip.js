@@ -0,0 +1,10 @@
+import { unwrapArray } from './arrays';
+
+async function builtin(runtime, signal, next, input, ...args) {
+  const arrays = [input, ...args].map((v) => unwrapArray(runtime, v));
+
+  const l = Math.min(...arrays.map((array) => array.length));
+  return next(Array.from({ length: l }, (_, i) => arrays.map((array) => array[i])));
+}
+
+export default builtin;
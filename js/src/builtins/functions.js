@@ -0,0 +1,44 @@
+import { JPLTypeError } from '../library';
+
+/** Unwrap the specified function argument */
+export function unwrapFunction(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'function') throw new JPLTypeError('cannot execute %s (%*<100v)', t, value);
+  return value;
+}
+
+/** Call the specified function and collect all of its outputs */
+export function callFunction(runtime, signal, fn, input, ...args) {
+  return fn(runtime, signal, (output) => [output], input, ...args);
+}
+
+/**
+ * Fold the outputs of gen, which is called with the specified input.
+ * For each output, f is called with the output as its input and the accumulator and index as its arguments.
+ * The last output of f becomes the new accumulator, which is passed to emit after each step.
+ *
+ * gen is executed sequentially, so that its outputs are folded in program order as they are produced.
+ * As native functions may still call the piper concurrently, the accumulator updates are serialized.
+ */
+export async function foldGenerator(runtime, signal, gen, f, input, initialValue, emit) {
+  let acc = initialValue;
+  let index = 0;
+  let pending = Promise.resolve();
+  const results = await gen(
+    runtime.sequential(),
+    signal,
+    (output) => {
+      const step = pending.then(async () => {
+        const accs = await callFunction(runtime, signal, f, output, acc, index);
+        index += 1;
+        if (accs.length > 0) acc = accs[accs.length - 1];
+        return emit(acc);
+      });
+      pending = step.catch(() => {});
+      return step;
+    },
+    input,
+  );
+  return { acc, results };
+}
@@ -71,8 +71,8 @@ func map(f): ([.[] | f()])
     )
   ) ?? ""
 )
-| func sortBy(f): ([.[] | [[f()], .]] | internals.sortEntries() | [.[][1]])
-| func sort(): (sortBy(func (): (.)))
+| func sortBy(f, options): ([.[] | [[f()], .]] | internals.sortEntries(options) | [.[][1]])
+| func sort(options): (sortBy(func (): (.), options))
 | func groupBy(f): (
   key = 0 | value = 1
   | [.[] | [[f()], [.]]] | internals.sortEntries()
@@ -96,6 +96,7 @@ func map(f): ([.[] | f()])
   | r(r)
 )
 | func recurse(cond): (recurseBy(func (): ((arrays(), objects()) | .[]), cond))
+| func leafPaths(): (paths(func (): (not contains->(["array", "object"], type()))))
 | func reverse(): ([.[length() - 1 - range(0, length())]])
 | func minBy(f): (
   key = 0 | value = 1
@@ -1,9 +1,19 @@
 import { nativeFunction } from '../library';
+import { compareKeys, unwrapSortOptions } from './sort';
 
-export const sortEntries = nativeFunction(async (runtime, input) => [
-  await runtime.alterValue(input, (value) =>
-    [...value].sort((a, b) =>
-      runtime.compareArrays(runtime.unwrapValue(a)[0], runtime.unwrapValue(b)[0]),
+export const sortEntries = nativeFunction(async (runtime, input, arg0) => {
+  const options = unwrapSortOptions(runtime, arg0);
+
+  return [
+    await runtime.alterValue(input, (value) =>
+      [...value].sort((a, b) =>
+        compareKeys(
+          runtime,
+          options,
+          runtime.unwrapValue(runtime.unwrapValue(a)[0]),
+          runtime.unwrapValue(runtime.unwrapValue(b)[0]),
+        ),
+      ),
     ),
-  ),
-]);
+  ];
+});
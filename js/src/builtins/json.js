@@ -0,0 +1,440 @@
+import { JPLRuntimeError, formatJSON } from '../library';
+
+/** Syntax error at the specified offset of a JSON document */
+class JSONSyntaxError {
+  constructor(message, offset) {
+    this.message = message;
+    this.offset = offset;
+  }
+}
+
+/** Maximum nesting depth of arrays and objects, which is the same as in the Go implementation */
+const MAX_DEPTH = 10000;
+
+const hexDigits = /^[0-9a-fA-F]+$/;
+
+const isLineTerminator = (c) => c === '\n' || c === '\r' || c === '\u2028' || c === '\u2029';
+
+const isIdentifierStart = (c) => c !== undefined && /^[\p{L}_$]$/u.test(c);
+
+const isIdentifierPart = (c) => c !== undefined && /^[\p{L}\p{Nd}_$]$/u.test(c);
+
+const isDigit = (c) => c !== undefined && c >= '0' && c <= '9';
+
+/**
+ * Parser for JSON documents, which optionally accepts JSON5 syntax.
+ * All offsets are counted in unicode code points.
+ */
+export class JSONParser {
+  constructor(signal, text, json5) {
+    this.signal = signal;
+    this.text = [...text];
+    this.pos = 0;
+    this.json5 = json5;
+    this.depth = 0;
+  }
+
+  fail(message) {
+    return new JSONSyntaxError(message, this.pos);
+  }
+
+  unexpected() {
+    if (this.pos >= this.text.length) return this.fail('unexpected end of input');
+    return this.fail(`unexpected character ${formatJSON(this.text[this.pos])}`);
+  }
+
+  /**
+   * Create a runtime error for the specified error, which contains its message and position.
+   * Errors that are not syntax errors are returned as is.
+   */
+  error(err) {
+    if (!(err instanceof JSONSyntaxError)) return err;
+    let line = 1;
+    let column = 1;
+    for (let i = 0; i < err.offset && i < this.text.length; i += 1) {
+      const c = this.text[i];
+      if (c === '\n' || (c === '\r' && this.text[i + 1] !== '\n')) {
+        line += 1;
+        column = 1;
+      } else {
+        column += 1;
+      }
+    }
+    return new JPLRuntimeError({ message: err.message, offset: err.offset, line, column });
+  }
+
+  peek(offset = 0) {
+    return this.text[this.pos + offset];
+  }
+
+  /**
+   * Parse all documents of the text.
+   * Unless multiple documents are allowed, the text must contain exactly one document.
+   */
+  parseDocuments(multiple) {
+    const results = [];
+    for (;;) {
+      this.skipWhitespace();
+      if (this.pos >= this.text.length && (multiple || results.length > 0)) return results;
+      if (results.length > 0 && !multiple) throw this.unexpected();
+      results.push(this.parseValue());
+    }
+  }
+
+  isWhitespace(c) {
+    switch (c) {
+      case ' ':
+      case '\t':
+      case '\n':
+      case '\r':
+        return true;
+      case '\v':
+      case '\f':
+      case '\u00a0':
+      case '\u2028':
+      case '\u2029':
+      case '\ufeff':
+        return this.json5;
+      default:
+        return this.json5 && c !== undefined && /^\p{Zs}$/u.test(c);
+    }
+  }
+
+  skipWhitespace() {
+    while (this.pos < this.text.length) {
+      const c = this.text[this.pos];
+      if (this.isWhitespace(c)) {
+        this.pos += 1;
+      } else if (this.json5 && c === '/' && this.peek(1) === '/') {
+        while (this.pos < this.text.length && !isLineTerminator(this.text[this.pos])) {
+          this.pos += 1;
+        }
+      } else if (this.json5 && c === '/' && this.peek(1) === '*') {
+        const start = this.pos;
+        this.pos += 2;
+        while (!(this.peek() === '*' && this.peek(1) === '/')) {
+          if (this.pos >= this.text.length) {
+            this.pos = start;
+            throw this.fail('unterminated comment');
+          }
+          this.pos += 1;
+        }
+        this.pos += 2;
+      } else {
+        return;
+      }
+    }
+  }
+
+  parseIdentifier() {
+    const start = this.pos;
+    if (isIdentifierStart(this.peek())) {
+      this.pos += 1;
+      while (isIdentifierPart(this.peek())) this.pos += 1;
+    }
+    return this.text.slice(start, this.pos).join('');
+  }
+
+  /** Parse the value at the current position, where arrays and objects are parsed iteratively */
+  parseValue() {
+    // Enclosing arrays and objects are kept on a stack, so that deeply nested documents cannot exceed the call stack
+    const stack = [];
+    for (;;) {
+      let value;
+      const c = this.peek();
+      if (c === '{' || c === '[') {
+        if (stack.length >= MAX_DEPTH) throw this.fail('exceeded max depth');
+        const container = c === '{' ? { result: {}, closing: '}' } : { result: [], closing: ']' };
+        this.pos += 1;
+        this.skipWhitespace();
+        if (this.peek() !== container.closing) {
+          this.signal.checkHealth();
+          if (container.closing === '}') container.key = this.parseKey();
+          stack.push(container);
+          continue;
+        }
+        this.pos += 1;
+        value = container.result;
+      } else {
+        value = this.parsePrimitive();
+      }
+
+      // Complete all enclosing arrays and objects that do not have any further entries
+      for (;;) {
+        const container = stack.at(-1);
+        if (!container) return value;
+        if (container.closing === '}') {
+          // Keys like `__proto__` must be defined as regular fields
+          Object.defineProperty(container.result, container.key, {
+            value,
+            writable: true,
+            enumerable: true,
+            configurable: true,
+          });
+        } else {
+          container.result.push(value);
+        }
+        if (!this.parseSeparator(container.closing)) {
+          this.signal.checkHealth();
+          if (container.closing === '}') container.key = this.parseKey();
+          break;
+        }
+        stack.pop();
+        value = container.result;
+      }
+    }
+  }
+
+  parsePrimitive() {
+    const c = this.peek();
+    if (c === '"' || (this.json5 && c === "'")) return this.parseString();
+    if (c === '-' || isDigit(c) || (this.json5 && (c === '+' || c === '.'))) {
+      return this.parseNumber();
+    }
+
+    const start = this.pos;
+    switch (this.parseIdentifier()) {
+      case 'true':
+        return true;
+      case 'false':
+        return false;
+      case 'null':
+        return null;
+      case 'Infinity':
+      case 'NaN':
+        if (this.json5) {
+          this.pos = start;
+          throw this.fail('number out of range');
+        }
+        break;
+      default:
+    }
+    this.pos = start;
+    throw this.unexpected();
+  }
+
+  /** Parse the key of the next object field including its colon */
+  parseKey() {
+    const c = this.peek();
+    let key;
+    if (c === '"' || (this.json5 && c === "'")) key = this.parseString();
+    else if (this.json5 && isIdentifierStart(c)) key = this.parseIdentifier();
+    else throw this.unexpected();
+
+    this.skipWhitespace();
+    if (this.peek() !== ':') throw this.unexpected();
+    this.pos += 1;
+    this.skipWhitespace();
+    return key;
+  }
+
+  /**
+   * Parse the separator after an entry of an array or object with the specified closing character.
+   * Returns true if the array or object has been closed.
+   */
+  parseSeparator(closing) {
+    this.skipWhitespace();
+    switch (this.peek()) {
+      case ',':
+        this.pos += 1;
+        this.skipWhitespace();
+        if (this.json5 && this.peek() === closing) {
+          this.pos += 1;
+          return true;
+        }
+        return false;
+      case closing:
+        this.pos += 1;
+        return true;
+      default:
+        throw this.unexpected();
+    }
+  }
+
+  parseString() {
+    const quote = this.text[this.pos];
+    this.pos += 1;
+    let units = [];
+    let result = '';
+    // Pending UTF-16 code units of unicode escapes are combined,
+    // so that escaped surrogate pairs are decoded properly
+    const flush = () => {
+      result += utf16Decode(units);
+      units = [];
+    };
+
+    for (;;) {
+      if (this.pos >= this.text.length) throw this.fail('unterminated string');
+      const c = this.text[this.pos];
+
+      if (c === quote) {
+        this.pos += 1;
+        flush();
+        return result;
+      }
+
+      if (c === '\\') {
+        const start = this.pos;
+        this.pos += 1;
+        const e = this.peek();
+        this.pos += 1;
+        const invalid = (message) => {
+          this.pos = start;
+          return this.fail(message);
+        };
+        if (e === 'u') {
+          const unit = this.parseHex(4);
+          if (unit === null) throw invalid('invalid unicode escape sequence');
+          units.push(unit);
+          continue;
+        }
+        flush();
+        switch (e) {
+          case '"':
+          case '\\':
+          case '/':
+            result += e;
+            break;
+          case 'b':
+            result += '\b';
+            break;
+          case 'f':
+            result += '\f';
+            break;
+          case 'n':
+            result += '\n';
+            break;
+          case 'r':
+            result += '\r';
+            break;
+          case 't':
+            result += '\t';
+            break;
+          default:
+            if (!this.json5 || e === undefined || (e >= '1' && e <= '9')) {
+              throw invalid('invalid escape sequence');
+            }
+            switch (e) {
+              case 'v':
+                result += '\v';
+                break;
+              case '0':
+                if (isDigit(this.peek())) throw invalid('invalid escape sequence');
+                result += '\0';
+                break;
+              case 'x': {
+                const code = this.parseHex(2);
+                if (code === null) throw invalid('invalid escape sequence');
+                result += String.fromCodePoint(code);
+                break;
+              }
+              case '\r':
+                // Line continuations are removed
+                if (this.peek() === '\n') this.pos += 1;
+                break;
+              default:
+                if (!isLineTerminator(e)) result += e;
+            }
+        }
+        continue;
+      }
+
+      if (c < ' ' && (!this.json5 || c === '\n' || c === '\r')) throw this.unexpected();
+
+      flush();
+      result += c;
+      this.pos += 1;
+    }
+  }
+
+  /** Parse the specified number of hexadecimal digits */
+  parseHex(digits) {
+    if (this.pos + digits > this.text.length) return null;
+    const hex = this.text.slice(this.pos, this.pos + digits).join('');
+    if (!hexDigits.test(hex)) return null;
+    this.pos += digits;
+    return parseInt(hex, 16);
+  }
+
+  parseNumber() {
+    const start = this.pos;
+    let sign = 1;
+    if (this.peek() === '-') {
+      sign = -1;
+      this.pos += 1;
+    } else if (this.peek() === '+') {
+      this.pos += 1;
+    }
+
+    let value;
+    const c = this.peek();
+    if (this.json5 && isIdentifierStart(c)) {
+      switch (this.parseIdentifier()) {
+        case 'Infinity':
+        case 'NaN':
+          this.pos = start;
+          throw this.fail('number out of range');
+        default:
+      }
+      this.pos = start + 1;
+      throw this.unexpected();
+    } else if (this.json5 && c === '0' && (this.peek(1) === 'x' || this.peek(1) === 'X')) {
+      this.pos += 2;
+      const digitsStart = this.pos;
+      while (this.pos < this.text.length && hexDigits.test(this.text[this.pos])) this.pos += 1;
+      if (this.pos === digitsStart) throw this.unexpected();
+      value = Number(`0x${this.text.slice(digitsStart, this.pos).join('')}`);
+    } else {
+      const numberStart = this.pos;
+      const intDigits = this.skipDigits();
+      if (intDigits > 1 && this.text[numberStart] === '0') {
+        this.pos = numberStart + 1;
+        throw this.unexpected();
+      }
+      if (intDigits === 0 && !(this.json5 && this.peek() === '.')) throw this.unexpected();
+      if (this.peek() === '.') {
+        this.pos += 1;
+        if (this.skipDigits() === 0 && !(this.json5 && intDigits > 0)) throw this.unexpected();
+      }
+      if (this.peek() === 'e' || this.peek() === 'E') {
+        this.pos += 1;
+        if (this.peek() === '+' || this.peek() === '-') this.pos += 1;
+        if (this.skipDigits() === 0) throw this.unexpected();
+      }
+      value = Number(this.text.slice(numberStart, this.pos).join(''));
+    }
+
+    if (!Number.isFinite(value)) {
+      this.pos = start;
+      throw this.fail('number out of range');
+    }
+    return sign * value;
+  }
+
+  skipDigits() {
+    const start = this.pos;
+    while (isDigit(this.peek())) this.pos += 1;
+    return this.pos - start;
+  }
+}
+
+/**
+ * Decode the specified UTF-16 code units,
+ * replacing unpaired surrogates with the unicode replacement character.
+ */
+function utf16Decode(units) {
+  let result = '';
+  for (let i = 0; i < units.length; i += 1) {
+    const u = units[i];
+    const next = units[i + 1];
+    if (u >= 0xd800 && u < 0xdc00 && next >= 0xdc00 && next < 0xe000) {
+      result += String.fromCharCode(u, next);
+      i += 1;
+    } else if (u >= 0xd800 && u < 0xe000) {
+      result += '\ufffd';
+    } else {
+      result += String.fromCharCode(u);
+    }
+  }
+  return result;
+}
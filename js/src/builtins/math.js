@@ -1,6 +1,6 @@
 import { JPLTypeError } from '../library';
 
-function unwrapNumber(runtime, v) {
+export function unwrapNumber(runtime, v) {
   const t = runtime.type(v);
   const u = runtime.unwrapValue(v);
   if (t !== 'number') {
@@ -1,19 +1,74 @@
+export { default as applyPatch } from './funcApplyPatch';
+export { default as chunk } from './funcChunk';
+export { default as compact } from './funcCompact';
 export { default as contains } from './funcContains';
+export { default as countBy } from './funcCountBy';
+export { default as deepMerge } from './funcDeepMerge';
+export { default as deletePaths } from './funcDeletePaths';
+export { default as diffPatch } from './funcDiffPatch';
+export { default as drop } from './funcDrop';
 export { default as endsWith } from './funcEndsWith';
 export { default as error } from './funcError';
+export { default as filterKeys } from './funcFilterKeys';
+export { default as flatten } from './funcFlatten';
+export { default as fold } from './funcFold';
+export { default as format } from './funcFormat';
 export { default as fromJSON } from './funcFromJSON';
+export { default as fromStream } from './funcFromStream';
+export { default as getPath } from './funcGetPath';
+export { default as getPointer } from './funcGetPointer';
+export { default as groupByKey } from './funcGroupByKey';
 export { default as has } from './funcHas';
 export { default as in } from './funcIn';
+export { default as indexBy } from './funcIndexBy';
+export { default as indexOf } from './funcIndexOf';
+export { default as indicesOf } from './funcIndicesOf';
+export { default as insertAt } from './funcInsertAt';
+export { default as invert } from './funcInvert';
+export { default as joinBy } from './funcJoinBy';
 export { default as keys } from './funcKeys';
 export { default as length } from './funcLength';
+export { default as mapKeys } from './funcMapKeys';
+export { default as matches } from './funcMatches';
+export { default as mergePatch } from './funcMergePatch';
 export { default as now } from './funcNow';
+export { default as omit } from './funcOmit';
+export { default as parseNumber } from './funcParseNumber';
+export { default as partition } from './funcPartition';
+export { default as paths } from './funcPaths';
+export { default as pick } from './funcPick';
+export { default as recurseWithPath } from './funcRecurseWithPath';
+export { default as renameKeys } from './funcRenameKeys';
+export { default as replace } from './funcReplace';
+export { default as rethrow } from './funcRethrow';
+export { default as scan } from './funcScan';
+export { default as setPath } from './funcSetPath';
+export { default as setPointer } from './funcSetPointer';
+export { default as sortWith } from './funcSortWith';
+export { default as split } from './funcSplit';
 export { default as startsWith } from './funcStartsWith';
+export { default as sumBy } from './funcSumBy';
+export { default as take } from './funcTake';
+export { default as test } from './funcTest';
+export { default as toBoolean } from './funcToBoolean';
+export { default as toExponential } from './funcToExponential';
+export { default as toFixed } from './funcToFixed';
+export { default as toInteger } from './funcToInteger';
 export { default as toJSON } from './funcToJSON';
 export { default as toNumber } from './funcToNumber';
+export { default as toPrecision } from './funcToPrecision';
+export { default as toStream } from './funcToStream';
 export { default as toString } from './funcToString';
+export { default as transpose } from './funcTranspose';
 export { default as trim } from './funcTrim';
 export { default as trimEnd } from './funcTrimEnd';
 export { default as trimStart } from './funcTrimStart';
 export { default as type } from './funcType';
+export { default as validate } from './funcValidate';
 export { default as void } from './funcVoid';
+export { default as walk } from './funcWalk';
+export { default as window } from './funcWindow';
+export { default as zip } from './funcZip';
 export * from './math';
+export * from './sets';
+export * from './stats';
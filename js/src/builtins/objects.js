@@ -0,0 +1,22 @@
+import { JPLTypeError } from '../library';
+
+/** Unwrap the specified value, which must be an object */
+export function unwrapObject(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'object') throw new JPLTypeError('%s (%*<100v) has no keys', t, value);
+  return value;
+}
+
+/** Unwrap the specified object key, which must be a string */
+export function unwrapKey(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'string') throw new JPLTypeError('cannot use %s (%*<100v) as object key', t, value);
+  return value;
+}
+
+/** Replace all fields of the specified object with the specified entries */
+export function replaceEntries(runtime, value, entries) {
+  return runtime.alterValue(value, () => Object.fromEntries(entries));
+}
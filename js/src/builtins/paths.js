@@ -0,0 +1,64 @@
+import { JPLTypeError } from '../library';
+
+/** Unwrap the specified path, which must be an array of keys */
+export function unwrapPath(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'array') throw new JPLTypeError('%s (%*<100v) cannot be used as a path', t, value);
+  return runtime.unwrapValues(value, 'path');
+}
+
+/** Unwrap the specified list of paths */
+export function unwrapPaths(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'array') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a list of paths', t, value);
+  }
+  return value.map((entry) => unwrapPath(runtime, entry));
+}
+
+/**
+ * Unwrap the specified list of paths,
+ * which may contain single keys as a shorthand for paths of length one.
+ */
+export function unwrapKeyPaths(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'array') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a list of paths', t, value);
+  }
+  return value.map((entry) => {
+    const key = runtime.unwrapValue(entry);
+    return runtime.type(key) === 'array' ? unwrapPath(runtime, key) : [key];
+  });
+}
+
+/**
+ * Visit all values below the specified value in pre-order.
+ * Array items are visited by index, object fields are visited in sorted key order.
+ */
+export async function walkPaths(runtime, signal, value, path, cb) {
+  signal.checkHealth();
+
+  const v = runtime.unwrapValue(value);
+  switch (runtime.type(v)) {
+    case 'array':
+      for (let i = 0; i < v.length; i += 1) {
+        const p = [...path, i];
+        await cb(p, v[i]);
+        await walkPaths(runtime, signal, v[i], p, cb);
+      }
+      break;
+
+    case 'object':
+      for (const key of Object.keys(v).sort()) {
+        const p = [...path, key];
+        await cb(p, v[key]);
+        await walkPaths(runtime, signal, v[key], p, cb);
+      }
+      break;
+
+    default:
+  }
+}
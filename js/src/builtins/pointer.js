@@ -0,0 +1,108 @@
+import { JPLRuntimeError, JPLTypeError } from '../library';
+
+const pointerInvalidEscape = /~([^01]|$)/;
+
+const pointerArrayIndex = /^(0|[1-9][0-9]*)$/;
+
+/**
+ * Unwrap the specified JSON pointer (RFC 6901)
+ * and resolve it to a path of keys within the specified value.
+ */
+export function resolvePointer(runtime, value, v) {
+  const pointer = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(pointer);
+  if (t !== 'string') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as a JSON pointer', t, pointer);
+  }
+  const tokens = parsePointer(pointer);
+  if (!tokens) throw new JPLRuntimeError('invalid JSON pointer (%*<100v)', pointer);
+  const [path, message] = pointerPath(runtime, value, tokens);
+  if (message) {
+    throw new JPLRuntimeError('cannot resolve JSON pointer (%*<100v): %s', pointer, message);
+  }
+  return path;
+}
+
+/**
+ * Parse the specified JSON pointer (RFC 6901) into its unescaped reference tokens.
+ * The result is null if the pointer is malformed.
+ */
+export function parsePointer(pointer) {
+  if (pointer === '') return [];
+  if (pointer[0] !== '/' || pointerInvalidEscape.test(pointer)) return null;
+  return pointer
+    .slice(1)
+    .split('/')
+    .map((token) => token.replace(/~[01]/g, (escape) => (escape === '~1' ? '/' : '~')));
+}
+
+/** Format the specified path of keys as a JSON pointer (RFC 6901) */
+export function formatPointer(path) {
+  return path
+    .map((key) => `/${String(key).replace(/~/g, '~0').replace(/\//g, '~1')}`)
+    .join('');
+}
+
+/**
+ * Resolve the specified reference tokens to a path of keys within the specified value.
+ *
+ * Tokens that refer to array items must be valid array indices or `-`, which refers to the end of the array.
+ * Tokens that refer to values that do not exist are treated as object keys.
+ * If the tokens cannot be resolved, a message describing the problem is returned.
+ */
+export function pointerPath(runtime, value, tokens) {
+  const path = [];
+  let current = value;
+  for (const token of tokens) {
+    const v = runtime.unwrapValue(current ?? null);
+
+    switch (runtime.type(v)) {
+      case 'array': {
+        let index = v.length;
+        if (token !== '-') {
+          if (!pointerArrayIndex.test(token)) {
+            return [null, `invalid array index ${JSON.stringify(token)}`];
+          }
+          index = +token;
+        }
+        path.push(index);
+        current = index < v.length ? v[index] : null;
+        break;
+      }
+
+      case 'object':
+        path.push(token);
+        current = Object.hasOwn(v, token) ? v[token] : null;
+        break;
+
+      default:
+        path.push(token);
+        current = null;
+    }
+  }
+  return [path, ''];
+}
+
+/** Resolve the value at the specified path of keys, reporting whether it exists */
+export function lookupPath(runtime, value, path) {
+  let current = value;
+  for (const key of path) {
+    const v = runtime.unwrapValue(current ?? null);
+
+    switch (runtime.type(v)) {
+      case 'array':
+        if (typeof key !== 'number' || key >= v.length) return [null, false];
+        current = v[key];
+        break;
+
+      case 'object':
+        if (typeof key !== 'string' || !Object.hasOwn(v, key)) return [null, false];
+        current = v[key];
+        break;
+
+      default:
+        return [null, false];
+    }
+  }
+  return [current, true];
+}
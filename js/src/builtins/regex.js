@@ -0,0 +1,26 @@
+import { JPLTypeError, resolveRegex } from '../library';
+
+/** Unwrap the specified value, which must be a string that can be matched against a regular expression */
+export function unwrapSubject(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t !== 'string') {
+    throw new JPLTypeError(
+      '%s (%*<100v) cannot be matched against a regular expression',
+      t,
+      value,
+    );
+  }
+  return value;
+}
+
+/** Unwrap the specified regular expression argument */
+export function unwrapRegex(runtime, v) {
+  const regex = resolveRegex(v ?? null);
+  if (!regex) {
+    const value = runtime.unwrapValue(v ?? null);
+    const t = runtime.type(value);
+    throw new JPLTypeError('cannot use %s (%*<100v) as regular expression', t, value);
+  }
+  return regex;
+}
@@ -0,0 +1,601 @@
+import { JPLRuntimeError, JPLTypeError, template } from '../library';
+import { formatPointer, lookupPath, parsePointer, pointerPath } from './pointer';
+
+/**
+ * Outcome of validating an instance against a schema.
+ * Evaluated properties and items are tracked for `unevaluatedProperties` and `unevaluatedItems`.
+ */
+class SchemaResult {
+  errors = [];
+
+  properties = new Set();
+
+  items = new Set();
+
+  get valid() {
+    return this.errors.length === 0;
+  }
+
+  /**
+   * Include the outcome of a subschema that has been applied to the same instance.
+   * Annotations are only kept if the subschema has been successful.
+   */
+  include(other, keepErrors) {
+    if (keepErrors) this.errors.push(...other.errors);
+    if (!other.valid) return;
+    other.properties.forEach((key) => this.properties.add(key));
+    other.items.forEach((i) => this.items.add(i));
+  }
+}
+
+/**
+ * Validator for JSON schemas (draft 2020-12).
+ * Both the schema and the instance are expected to be stripped JSON values.
+ */
+export class SchemaValidator {
+  ids = {};
+
+  anchors = {};
+
+  patterns = {};
+
+  active = new Set();
+
+  constructor(runtime, signal, schema) {
+    this.runtime = runtime;
+    this.signal = signal;
+    this.root = isObject(schema) ? schema : null;
+    this.collect(schema);
+  }
+
+  /** Collect all identifiers and anchors of the specified schema and its subschemas */
+  collect(schema) {
+    if (Array.isArray(schema)) {
+      schema.forEach((value) => this.collect(value));
+      return;
+    }
+    if (!isObject(schema)) return;
+
+    if (typeof schema.$id === 'string') this.ids[schema.$id.replace(/#$/, '')] = schema;
+    if (typeof schema.$anchor === 'string') this.anchors[schema.$anchor] = schema;
+    if (typeof schema.$dynamicAnchor === 'string') this.anchors[schema.$dynamicAnchor] = schema;
+    Object.entries(schema).forEach(([key, value]) => {
+      if (['const', 'default', 'enum', 'examples'].includes(key)) return;
+      this.collect(value);
+    });
+  }
+
+  /** Record a validation error */
+  fail(r, ip, sp, tmpl, ...replacements) {
+    r.errors.push({
+      instancePath: formatPointer(ip),
+      schemaPath: formatPointer(sp),
+      message: template(tmpl, ...replacements),
+    });
+  }
+
+  /** Compile the specified regular expression, which is cached for subsequent validations */
+  pattern(pattern) {
+    if (Object.hasOwn(this.patterns, pattern)) return this.patterns[pattern];
+    let re;
+    try {
+      re = new RegExp(pattern, 'u');
+    } catch {
+      throw new JPLRuntimeError('invalid pattern (%*<100v) in JSON schema', pattern);
+    }
+    this.patterns[pattern] = re;
+    return re;
+  }
+
+  /**
+   * Resolve the specified schema reference.
+   *
+   * References may point to the root schema (`#`), JSON pointers within the root schema (`#/$defs/a`),
+   * anchors (`#a`) and identifiers of embedded schemas, optionally followed by a fragment.
+   */
+  resolve(ref) {
+    const i = ref.indexOf('#');
+    const base = i >= 0 ? ref.slice(0, i) : ref;
+    const fragment = i >= 0 ? ref.slice(i + 1) : '';
+    let schema = this.root;
+    if (base !== '') {
+      if (!Object.hasOwn(this.ids, base)) return [null, false];
+      schema = this.ids[base];
+    }
+    if (fragment !== '' && fragment[0] !== '/') {
+      if (!Object.hasOwn(this.anchors, fragment)) return [null, false];
+      return [this.anchors[fragment], true];
+    }
+    const tokens = parsePointer(fragment);
+    if (!tokens) return [null, false];
+    const [path, message] = pointerPath(this.runtime, schema, tokens);
+    if (message) return [null, false];
+    return lookupPath(this.runtime, schema, path);
+  }
+
+  /** Validate the specified instance against the specified schema */
+  validate(instance, schema, ip, sp) {
+    this.signal.checkHealth();
+
+    const r = new SchemaResult();
+    if (typeof schema === 'boolean') {
+      if (!schema) this.fail(r, ip, sp, 'value is not allowed');
+    } else if (isObject(schema)) {
+      this.validateObject(r, instance, schema, ip, sp);
+    } else {
+      const t = this.runtime.type(schema);
+      throw new JPLTypeError('%s (%*<100v) cannot be used as a JSON schema', t, schema);
+    }
+    return r;
+  }
+
+  validateObject(r, instance, s, ip, sp) {
+    const at = (...keys) => [...sp, ...keys];
+    const child = (key) => [...ip, key];
+
+    for (const keyword of ['$ref', '$dynamicRef']) {
+      const ref = s[keyword];
+      if (typeof ref !== 'string') continue;
+      const [target, found] = this.resolve(ref);
+      if (!found) throw new JPLRuntimeError('cannot resolve JSON schema reference (%*<100v)', ref);
+      // Applying the same reference to the same instance again would never terminate
+      const key = `${ref}\x00${formatPointer(ip)}`;
+      if (this.active.has(key)) {
+        throw new JPLRuntimeError('circular JSON schema reference (%*<100v)', ref);
+      }
+      this.active.add(key);
+      try {
+        r.include(this.validate(instance, target, ip, at(keyword)), true);
+      } finally {
+        this.active.delete(key);
+      }
+    }
+
+    const t = this.runtime.type(instance);
+
+    const types = schemaTypes(s.type);
+    if (types && !types.some((name) => schemaTypeMatches(name, t, instance))) {
+      this.fail(r, ip, at('type'), 'expected %s, got %s', types.join(' or '), t);
+    }
+    if (Array.isArray(s.enum) && !s.enum.some((value) => this.runtime.equals(instance, value))) {
+      this.fail(r, ip, at('enum'), 'value must be one of %*<100v', s.enum);
+    }
+    if (Object.hasOwn(s, 'const') && !this.runtime.equals(instance, s.const)) {
+      this.fail(r, ip, at('const'), 'value must be %*<100v', s.const);
+    }
+
+    switch (t) {
+      case 'number': {
+        const { multipleOf } = s;
+        if (typeof multipleOf === 'number' && multipleOf > 0) {
+          const q = instance / multipleOf;
+          // The quotient is compared with a relative tolerance, as decimal fractions like 0.1 cannot be represented exactly
+          if (
+            !Number.isFinite(q) ||
+            Math.abs(q - Math.round(q)) > 1e-9 * Math.max(1, Math.abs(q))
+          ) {
+            this.fail(r, ip, at('multipleOf'), 'value must be a multiple of %v', multipleOf);
+          }
+        }
+        if (typeof s.maximum === 'number' && instance > s.maximum) {
+          this.fail(r, ip, at('maximum'), 'value must be at most %v', s.maximum);
+        }
+        if (typeof s.exclusiveMaximum === 'number' && instance >= s.exclusiveMaximum) {
+          this.fail(
+            r,
+            ip,
+            at('exclusiveMaximum'),
+            'value must be less than %v',
+            s.exclusiveMaximum,
+          );
+        }
+        if (typeof s.minimum === 'number' && instance < s.minimum) {
+          this.fail(r, ip, at('minimum'), 'value must be at least %v', s.minimum);
+        }
+        if (typeof s.exclusiveMinimum === 'number' && instance <= s.exclusiveMinimum) {
+          this.fail(
+            r,
+            ip,
+            at('exclusiveMinimum'),
+            'value must be greater than %v',
+            s.exclusiveMinimum,
+          );
+        }
+        break;
+      }
+
+      case 'string': {
+        const l = [...instance].length;
+        if (typeof s.maxLength === 'number' && l > s.maxLength) {
+          this.fail(
+            r,
+            ip,
+            at('maxLength'),
+            'string must be at most %v characters long',
+            s.maxLength,
+          );
+        }
+        if (typeof s.minLength === 'number' && l < s.minLength) {
+          this.fail(
+            r,
+            ip,
+            at('minLength'),
+            'string must be at least %v characters long',
+            s.minLength,
+          );
+        }
+        if (typeof s.pattern === 'string' && !this.pattern(s.pattern).test(instance)) {
+          this.fail(r, ip, at('pattern'), 'string must match pattern %v', s.pattern);
+        }
+        if (typeof s.format === 'string' && !schemaFormatMatches(s.format, instance)) {
+          this.fail(r, ip, at('format'), 'string must be a valid %s', s.format);
+        }
+        break;
+      }
+
+      case 'array':
+        this.validateArray(r, instance, s, ip, at, child);
+        break;
+
+      case 'object':
+        this.validateProperties(r, instance, s, ip, at, child);
+        break;
+
+      default:
+    }
+
+    if (Array.isArray(s.allOf)) {
+      s.allOf.forEach((schema, i) => {
+        r.include(this.validate(instance, schema, ip, at('allOf', i)), true);
+      });
+    }
+    if (Array.isArray(s.anyOf)) {
+      let matches = 0;
+      s.anyOf.forEach((schema, i) => {
+        const sub = this.validate(instance, schema, ip, at('anyOf', i));
+        if (sub.valid) matches += 1;
+        r.include(sub, false);
+      });
+      if (matches === 0) this.fail(r, ip, at('anyOf'), 'value must match at least one schema');
+    }
+    if (Array.isArray(s.oneOf)) {
+      let matches = 0;
+      s.oneOf.forEach((schema, i) => {
+        const sub = this.validate(instance, schema, ip, at('oneOf', i));
+        if (sub.valid) matches += 1;
+        r.include(sub, false);
+      });
+      if (matches !== 1) {
+        this.fail(
+          r,
+          ip,
+          at('oneOf'),
+          'value must match exactly one schema, but matches %v',
+          matches,
+        );
+      }
+    }
+    if (Object.hasOwn(s, 'not')) {
+      if (this.validate(instance, s.not, ip, at('not')).valid) {
+        this.fail(r, ip, at('not'), 'value must not match schema');
+      }
+    }
+    if (Object.hasOwn(s, 'if')) {
+      const sub = this.validate(instance, s.if, ip, at('if'));
+      r.include(sub, false);
+      const branch = sub.valid ? 'then' : 'else';
+      if (Object.hasOwn(s, branch)) {
+        r.include(this.validate(instance, s[branch], ip, at(branch)), true);
+      }
+    }
+
+    // Unevaluated items and properties depend on the annotations of all other keywords
+    switch (t) {
+      case 'array':
+        if (Object.hasOwn(s, 'unevaluatedItems')) {
+          instance.forEach((item, i) => {
+            if (r.items.has(i)) return;
+            const sub = this.validate(item, s.unevaluatedItems, child(i), at('unevaluatedItems'));
+            r.errors.push(...sub.errors);
+            r.items.add(i);
+          });
+        }
+        break;
+
+      case 'object':
+        if (Object.hasOwn(s, 'unevaluatedProperties')) {
+          for (const key of Object.keys(instance).sort()) {
+            if (r.properties.has(key)) continue;
+            const sub = this.validate(
+              instance[key],
+              s.unevaluatedProperties,
+              child(key),
+              at('unevaluatedProperties'),
+            );
+            r.errors.push(...sub.errors);
+            r.properties.add(key);
+          }
+        }
+        break;
+
+      default:
+    }
+  }
+
+  validateArray(r, value, s, ip, at, child) {
+    const l = value.length;
+    if (typeof s.maxItems === 'number' && l > s.maxItems) {
+      this.fail(r, ip, at('maxItems'), 'array must have at most %v items', s.maxItems);
+    }
+    if (typeof s.minItems === 'number' && l < s.minItems) {
+      this.fail(r, ip, at('minItems'), 'array must have at least %v items', s.minItems);
+    }
+    if (s.uniqueItems === true) {
+      outer: for (let i = 0; i < l; i += 1) {
+        for (let j = i + 1; j < l; j += 1) {
+          if (this.runtime.equals(value[i], value[j])) {
+            this.fail(
+              r,
+              ip,
+              at('uniqueItems'),
+              'array items must be unique, but items %v and %v are equal',
+              i,
+              j,
+            );
+            break outer;
+          }
+        }
+      }
+    }
+
+    let prefix = 0;
+    if (Array.isArray(s.prefixItems)) {
+      s.prefixItems.forEach((schema, i) => {
+        if (i >= l) return;
+        const sub = this.validate(value[i], schema, child(i), at('prefixItems', i));
+        r.errors.push(...sub.errors);
+        r.items.add(i);
+      });
+      prefix = s.prefixItems.length;
+    }
+    if (Object.hasOwn(s, 'items')) {
+      for (let i = prefix; i < l; i += 1) {
+        const sub = this.validate(value[i], s.items, child(i), at('items'));
+        r.errors.push(...sub.errors);
+        r.items.add(i);
+      }
+    }
+    if (Object.hasOwn(s, 'contains')) {
+      let matches = 0;
+      value.forEach((item, i) => {
+        if (this.validate(item, s.contains, child(i), at('contains')).valid) {
+          matches += 1;
+          r.items.add(i);
+        }
+      });
+      const minContains = typeof s.minContains === 'number' ? s.minContains : 1;
+      if (matches < minContains) {
+        this.fail(
+          r,
+          ip,
+          at('contains'),
+          'array must contain at least %v matching items',
+          minContains,
+        );
+      }
+      if (typeof s.maxContains === 'number' && matches > s.maxContains) {
+        this.fail(
+          r,
+          ip,
+          at('maxContains'),
+          'array must contain at most %v matching items',
+          s.maxContains,
+        );
+      }
+    }
+  }
+
+  validateProperties(r, value, s, ip, at, child) {
+    const keys = Object.keys(value).sort();
+
+    const l = keys.length;
+    if (typeof s.maxProperties === 'number' && l > s.maxProperties) {
+      this.fail(
+        r,
+        ip,
+        at('maxProperties'),
+        'object must have at most %v properties',
+        s.maxProperties,
+      );
+    }
+    if (typeof s.minProperties === 'number' && l < s.minProperties) {
+      this.fail(
+        r,
+        ip,
+        at('minProperties'),
+        'object must have at least %v properties',
+        s.minProperties,
+      );
+    }
+    if (Array.isArray(s.required)) {
+      s.required.forEach((key) => {
+        if (typeof key === 'string' && !Object.hasOwn(value, key)) {
+          this.fail(r, ip, at('required'), 'missing required property %v', key);
+        }
+      });
+    }
+    if (isObject(s.dependentRequired)) {
+      for (const key of Object.keys(s.dependentRequired).sort()) {
+        const required = s.dependentRequired[key];
+        if (!Array.isArray(required) || !Object.hasOwn(value, key)) continue;
+        required.forEach((dependency) => {
+          if (typeof dependency === 'string' && !Object.hasOwn(value, dependency)) {
+            this.fail(
+              r,
+              ip,
+              at('dependentRequired', key),
+              'property %v requires property %v',
+              key,
+              dependency,
+            );
+          }
+        });
+      }
+    }
+
+    const properties = isObject(s.properties) ? s.properties : {};
+    const patternProperties = isObject(s.patternProperties) ? s.patternProperties : {};
+    const patterns = Object.keys(patternProperties).sort();
+    for (const key of keys) {
+      let matched = false;
+      if (Object.hasOwn(properties, key)) {
+        matched = true;
+        const sub = this.validate(value[key], properties[key], child(key), at('properties', key));
+        r.errors.push(...sub.errors);
+      }
+      for (const pattern of patterns) {
+        if (!this.pattern(pattern).test(key)) continue;
+        matched = true;
+        const sub = this.validate(
+          value[key],
+          patternProperties[pattern],
+          child(key),
+          at('patternProperties', pattern),
+        );
+        r.errors.push(...sub.errors);
+      }
+      if (!matched && Object.hasOwn(s, 'additionalProperties')) {
+        matched = true;
+        const sub = this.validate(
+          value[key],
+          s.additionalProperties,
+          child(key),
+          at('additionalProperties'),
+        );
+        r.errors.push(...sub.errors);
+      }
+      if (matched) r.properties.add(key);
+    }
+
+    if (Object.hasOwn(s, 'propertyNames')) {
+      for (const key of keys) {
+        const sub = this.validate(key, s.propertyNames, child(key), at('propertyNames'));
+        r.errors.push(...sub.errors);
+      }
+    }
+    if (isObject(s.dependentSchemas)) {
+      for (const key of Object.keys(s.dependentSchemas).sort()) {
+        if (!Object.hasOwn(value, key)) continue;
+        const sub = this.validate(value, s.dependentSchemas[key], ip, at('dependentSchemas', key));
+        r.include(sub, true);
+      }
+    }
+  }
+}
+
+function isObject(value) {
+  return typeof value === 'object' && value !== null && !Array.isArray(value);
+}
+
+/**
+ * Unwrap the value of the `type` keyword,
+ * which is either a single type name or a list of type names.
+ */
+function schemaTypes(value) {
+  if (typeof value === 'string') return [value];
+  if (Array.isArray(value)) return value.filter((name) => typeof name === 'string');
+  return null;
+}
+
+function schemaTypeMatches(name, t, value) {
+  if (name === 'integer') return Number.isInteger(value);
+  return name === t;
+}
+
+const formatDate = /^(\d{4})-(0[1-9]|1[0-2])-(0[1-9]|[12]\d|3[01])$/;
+const formatTime =
+  /^([01]\d|2[0-3]):[0-5]\d:([0-5]\d|60)(\.\d+)?([Zz]|[+-]([01]\d|2[0-3]):[0-5]\d)$/;
+const formatEmail = /^[^\s@]+@[^\s@]+$/;
+const formatHostname =
+  /^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$/;
+const formatIPv4 = /^((25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)\.){3}(25[0-5]|2[0-4]\d|1\d\d|[1-9]?\d)$/;
+const formatIPv6 = /^[0-9A-Fa-f]{1,4}$/;
+const formatURI = /^[A-Za-z][A-Za-z0-9+.-]*:[^\s]*$/;
+const formatURIRef = /^[^\s]*$/;
+const formatUUID =
+  /^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$/;
+
+/**
+ * Check whether the specified string matches the specified format.
+ * Unknown formats are always considered to match.
+ */
+function schemaFormatMatches(format, value) {
+  switch (format) {
+    case 'date-time': {
+      const i = value.search(/[Tt]/);
+      return i >= 0 && isDate(value.slice(0, i)) && formatTime.test(value.slice(i + 1));
+    }
+    case 'date':
+      return isDate(value);
+    case 'time':
+      return formatTime.test(value);
+    case 'email':
+      return formatEmail.test(value);
+    case 'hostname':
+      return value.length <= 253 && formatHostname.test(value);
+    case 'ipv4':
+      return formatIPv4.test(value);
+    case 'ipv6':
+      return isIPv6(value);
+    case 'uri':
+      return formatURI.test(value);
+    case 'uri-reference':
+      return formatURIRef.test(value);
+    case 'uuid':
+      return formatUUID.test(value);
+    case 'regex':
+      try {
+        RegExp(value, 'u');
+        return true;
+      } catch {
+        return false;
+      }
+    case 'json-pointer':
+      return parsePointer(value) !== null;
+    default:
+      return true;
+  }
+}
+
+function isDate(value) {
+  const parts = formatDate.exec(value);
+  if (!parts) return false;
+  const [, year, month, day] = parts.map(Number);
+  const date = new Date(0);
+  date.setUTCFullYear(year, month - 1, day);
+  return date.getUTCDate() === day;
+}
+
+function isIPv6(value) {
+  let v = value;
+  let groups = 8;
+  // An embedded IPv4 address takes the place of the last two groups
+  const i = v.lastIndexOf(':');
+  if (i >= 0 && v.slice(i + 1).includes('.')) {
+    if (!formatIPv4.test(v.slice(i + 1))) return false;
+    v = `${v.slice(0, i + 1)}0`;
+    groups = 7;
+  }
+  const parts = v.split('::');
+  if (parts.length > 2) return false;
+  let count = 0;
+  for (const part of parts) {
+    if (part === '') continue;
+    for (const group of part.split(':')) {
+      if (!formatIPv6.test(group)) return false;
+      count += 1;
+    }
+  }
+  if (parts.length === 2) return count < groups;
+  return count === groups;
+}
@@ -0,0 +1,96 @@
+import { unwrapArray } from './arrays';
+import { callFunction, unwrapFunction } from './functions';
+
+/**
+ * Create a key for the specified value,
+ * which is the same for all values that are considered equal.
+ */
+export function hashKey(runtime, value) {
+  return JSON.stringify(canonicalize(runtime.stripJSON(value)));
+}
+
+/** Sort the keys of all objects, so that equal values are stringified equally */
+function canonicalize(value) {
+  if (Array.isArray(value)) return value.map(canonicalize);
+  if (typeof value !== 'object' || value === null) return value;
+  return Object.fromEntries(
+    Object.keys(value)
+      .sort()
+      .map((key) => [key, canonicalize(value[key])]),
+  );
+}
+
+/**
+ * Create keys for all specified items.
+ * If a function is specified, items are keyed by all of its outputs, otherwise by themselves.
+ */
+export async function hashKeys(runtime, signal, items, f) {
+  const keys = [];
+  for (const item of items) {
+    signal.checkHealth();
+    const key = f ? await callFunction(runtime, signal, f, item) : item;
+    keys.push(hashKey(runtime, key));
+  }
+  return keys;
+}
+
+/**
+ * Create a set operation builtin, which selects the items of both arrays based on their keys.
+ * If `by` is set, the builtin accepts a function as its second argument, which is used for keying the items.
+ */
+function funcSet(by, op) {
+  return async function builtin(runtime, signal, next, input, arg0, arg1) {
+    const a = unwrapArray(runtime, input);
+    const b = unwrapArray(runtime, arg0);
+    const f = by ? unwrapFunction(runtime, arg1) : null;
+
+    const ka = await hashKeys(runtime, signal, a, f);
+    const kb = await hashKeys(runtime, signal, b, f);
+
+    const [selectA, selectB] = op(ka, kb);
+    return next([...a.filter((_, i) => selectA[i]), ...b.filter((_, i) => selectB[i])]);
+  };
+}
+
+/** Select the first occurrence of each key that matches the specified filter */
+function selectUnique(keys, seen, filter) {
+  return keys.map((key) => {
+    if (seen.has(key) || !filter(key)) return false;
+    seen.add(key);
+    return true;
+  });
+}
+
+function unionSets(a, b) {
+  const seen = new Set();
+  return [selectUnique(a, seen, () => true), selectUnique(b, seen, () => true)];
+}
+
+function intersectionSets(a, b) {
+  const inB = new Set(b);
+  return [selectUnique(a, new Set(), (key) => inB.has(key)), []];
+}
+
+function differenceSets(a, b) {
+  const inB = new Set(b);
+  return [selectUnique(a, new Set(), (key) => !inB.has(key)), []];
+}
+
+function symmetricDifferenceSets(a, b) {
+  const inA = new Set(a);
+  const inB = new Set(b);
+  const seen = new Set();
+  return [
+    selectUnique(a, seen, (key) => !inB.has(key)),
+    selectUnique(b, seen, (key) => !inA.has(key)),
+  ];
+}
+
+export const union = funcSet(false, unionSets);
+export const unionBy = funcSet(true, unionSets);
+export const intersection = funcSet(false, intersectionSets);
+export const intersectionBy = funcSet(true, intersectionSets);
+export const difference = funcSet(false, differenceSets);
+export const differenceBy = funcSet(true, differenceSets);
+export const symmetricDifference = funcSet(false, symmetricDifferenceSets);
+export const symmetricDifferenceBy = funcSet(true, symmetricDifferenceSets);
@@ -0,0 +1,97 @@
+import { JPLRuntimeError, JPLTypeError } from '../library';
+
+/** Unwrap the specified sort options, which must be an object or null */
+export function unwrapSortOptions(runtime, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  const t = runtime.type(value);
+  if (t === 'null') {
+    return { descending: [], descendingRest: false, nulls: null, caseInsensitive: false };
+  }
+  if (t !== 'object') {
+    throw new JPLTypeError('%s (%*<100v) cannot be used as sort options', t, value);
+  }
+
+  const order = runtime.unwrapValue(value.order ?? null);
+  const descending = Array.isArray(order) ? order.map((o) => unwrapSortOrder(runtime, o)) : [];
+  // A single direction applies to all keys
+  const descendingRest = !Array.isArray(order) && unwrapSortOrder(runtime, order);
+
+  const nulls = runtime.unwrapValue(value.nulls ?? null);
+  if (nulls !== null && nulls !== 'first' && nulls !== 'last') {
+    throw new JPLRuntimeError(
+      'invalid null placement (%*<100v), expected "first" or "last"',
+      nulls,
+    );
+  }
+
+  const caseInsensitive = runtime.unwrapValue(value.caseInsensitive ?? null) === true;
+  return { descending, descendingRest, nulls, caseInsensitive };
+}
+
+/** Unwrap the specified sort direction and return whether it is descending */
+function unwrapSortOrder(runtime, v) {
+  const o = runtime.unwrapValue(v ?? null);
+  if (o !== null && o !== 'asc' && o !== 'desc') {
+    throw new JPLRuntimeError('invalid sort order (%*<100v), expected "asc" or "desc"', o);
+  }
+  return o === 'desc';
+}
+
+/** Return whether the sort key at index i is sorted in descending order */
+function isDescending(options, i) {
+  return i < options.descending.length ? options.descending[i] : options.descendingRest;
+}
+
+/** Compare the specified arrays of sort keys based on their lexical order */
+export function compareKeys(runtime, options, a, b) {
+  const min = Math.min(a.length, b.length);
+  for (let i = 0; i < min; i += 1) {
+    const c = compareKey(runtime, options, i, a[i], b[i]);
+    if (c !== 0) return c;
+  }
+  // Shorter arrays of keys are sorted first, unless the first missing key is sorted in descending order
+  const c = a.length - b.length;
+  return isDescending(options, min) ? -c : c;
+}
+
+/** Compare the specified sort keys at index i */
+function compareKey(runtime, options, i, a, b) {
+  const ua = runtime.unwrapValue(a);
+  const ub = runtime.unwrapValue(b);
+
+  if (options.nulls && (ua === null) !== (ub === null)) {
+    // Null placement does not depend on the sort direction
+    return (ua === null) === (options.nulls === 'first') ? -1 : 1;
+  }
+
+  const c =
+    options.caseInsensitive && typeof ua === 'string' && typeof ub === 'string'
+      ? runtime.compareStrings(ua.toLowerCase(), ub.toLowerCase())
+      : runtime.compare(ua, ub);
+  return isDescending(options, i) ? -c : c;
+}
+
+/**
+ * Sort the specified items stably using the specified asynchronous comparison function.
+ * If the comparison fails, sorting is aborted.
+ */
+export async function sortStable(items, cmp) {
+  if (items.length <= 1) return [...items];
+  const middle = Math.floor(items.length / 2);
+  const left = await sortStable(items.slice(0, middle), cmp);
+  const right = await sortStable(items.slice(middle), cmp);
+
+  const result = [];
+  let i = 0;
+  let j = 0;
+  while (i < left.length && j < right.length) {
+    if ((await cmp(left[i], right[j])) <= 0) {
+      result.push(left[i]);
+      i += 1;
+    } else {
+      result.push(right[j]);
+      j += 1;
+    }
+  }
+  return [...result, ...left.slice(i), ...right.slice(j)];
+}
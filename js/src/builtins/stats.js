@@ -0,0 +1,113 @@
+import { JPLRuntimeError } from '../library';
+import { unwrapArray, unwrapCount } from './arrays';
+import { unwrapNumber } from './math';
+
+/** Unwrap the specified array of numbers */
+function unwrapNumbers(runtime, v) {
+  return unwrapArray(runtime, v).map((item) => unwrapNumber(runtime, item));
+}
+
+function funcAggregate(aggregate) {
+  return async function builtin(runtime, signal, next, input, ...args) {
+    const values = unwrapNumbers(runtime, input);
+    return next(aggregate(runtime, values, ...args));
+  };
+}
+
+function sumValues(values) {
+  let result = 0;
+  for (const value of values) result += value;
+  return result;
+}
+
+/** Calculate the population variance of the specified values */
+function varianceValues(values) {
+  const mean = sumValues(values) / values.length;
+  let result = 0;
+  for (const value of values) result += (value - mean) * (value - mean);
+  return result / values.length;
+}
+
+/**
+ * Calculate the specified percentile of the specified values
+ * using linear interpolation between the closest ranks.
+ */
+function percentileValues(values, p) {
+  const sorted = [...values].sort((a, b) => a - b);
+  const rank = (p / 100) * (sorted.length - 1);
+  const lo = Math.floor(rank);
+  const hi = Math.ceil(rank);
+  return sorted[lo] + (sorted[hi] - sorted[lo]) * (rank - lo);
+}
+
+/**
+ * Determine the bounds of all histogram buckets.
+ * Buckets are either specified by a count of equally sized buckets between the smallest and largest value,
+ * or by an array of ascending bounds.
+ */
+function histogramBounds(runtime, values, v) {
+  const value = runtime.unwrapValue(v ?? null);
+  if (Array.isArray(value)) {
+    const bounds = unwrapNumbers(runtime, value);
+    if (bounds.length < 2 || bounds.some((b, i) => i > 0 && bounds[i - 1] >= b)) {
+      throw new JPLRuntimeError(
+        'histogram bounds (%*<100v) must contain at least two strictly ascending numbers',
+        value,
+      );
+    }
+    return bounds;
+  }
+
+  const n = unwrapCount(runtime, value, 1);
+  if (values.length === 0) return [];
+  const lo = Math.min(...values);
+  const hi = Math.max(...values);
+  // The outer bounds are set exactly, as rounding could otherwise exclude the minimum or maximum
+  return Array.from({ length: n + 1 }, (_, i) => {
+    if (i === 0) return lo;
+    if (i === n) return hi;
+    return lo + ((hi - lo) * i) / n;
+  });
+}
+
+export const sum = funcAggregate((runtime, values) => sumValues(values));
+
+export const mean = funcAggregate((runtime, values) =>
+  values.length === 0 ? null : sumValues(values) / values.length,
+);
+
+export const median = funcAggregate((runtime, values) =>
+  values.length === 0 ? null : percentileValues(values, 50),
+);
+
+export const percentile = funcAggregate((runtime, values, arg0) => {
+  const p = unwrapNumber(runtime, arg0 ?? null);
+  if (p < 0 || p > 100) {
+    throw new JPLRuntimeError('percentile (%*<100v) must be between 0 and 100', p);
+  }
+  return values.length === 0 ? null : percentileValues(values, p);
+});
+
+export const variance = funcAggregate((runtime, values) =>
+  values.length === 0 ? null : varianceValues(values),
+);
+
+export const stddev = funcAggregate((runtime, values) =>
+  values.length === 0 ? null : Math.sqrt(varianceValues(values)),
+);
+
+export const histogram = funcAggregate((runtime, values, arg0) => {
+  const bounds = histogramBounds(runtime, values, arg0);
+
+  const counts = new Array(Math.max(bounds.length - 1, 0)).fill(0);
+  for (const value of values) {
+    // Values outside of the bounds are ignored, while the last bucket also includes its upper bound
+    let i = bounds.findIndex((b) => b >= value);
+    if (i < 0) i = bounds.length;
+    if (bounds[i] !== value) i -= 1;
+    if (i === counts.length && value === bounds[i]) i -= 1;
+    if (i >= 0 && i < counts.length) counts[i] += 1;
+  }
+
+  return counts.map((count, i) => ({ from: bounds[i], to: bounds[i + 1], count }));
+});
@@ -1,7 +1,17 @@
 import {
+  ERROR_KIND_ERROR,
+  ERROR_KIND_EXECUTION_ERROR,
+  ERROR_KIND_REFERENCE_ERROR,
+  ERROR_KIND_RUNTIME_ERROR,
+  ERROR_KIND_TYPE_CONVERSION_ERROR,
+  ERROR_KIND_TYPE_ERROR,
+  ERROR_KIND_ZERO_DIVISION_ERROR,
+  JPLRuntimeError,
+  JPLSyntaxError,
   OPA_FIELD,
   OPA_FUNCTION,
   OPA_ITER,
+  OPA_RECURSIVE_DESCENT,
   OPA_SLICE,
   OPC_EQUAL,
   OPC_GREATER,
@@ -9,6 +19,11 @@ import {
   OPC_LESS,
   OPC_LESSEQUAL,
   OPC_UNEQUAL,
+  OPD_ARRAY,
+  OPD_OBJECT,
+  OPD_TYPE,
+  OPD_VALUE,
+  OPD_VARIABLE,
   OPM_ADDITION,
   OPM_DIVISION,
   OPM_MULTIPLICATION,
@@ -26,14 +41,19 @@ import {
   OP_AND,
   OP_ARRAY_CONSTRUCTOR,
   OP_ASSIGNMENT,
+  OP_BREAK,
   OP_CALCULATION,
   OP_COMPARISON,
   OP_CONSTANT_FALSE,
   OP_CONSTANT_NULL,
   OP_CONSTANT_TRUE,
+  OP_DELETE,
+  OP_DESTRUCTURING_DEFINITION,
   OP_FUNCTION_DEFINITION,
   OP_IF,
   OP_INTERPOLATED_STRING,
+  OP_LABEL,
+  OP_MATCH,
   OP_NEGATION,
   OP_NOT,
   OP_NULL_COALESCENCE,
@@ -41,11 +61,14 @@ import {
   OP_OBJECT_CONSTRUCTOR,
   OP_OR,
   OP_OUTPUT_CONCAT,
+  OP_REGEX,
+  OP_SPREAD,
   OP_STRING,
   OP_TRY,
   OP_VARIABLE,
   OP_VARIABLE_DEFINITION,
   OP_VOID,
+  compileRegex,
 } from '../library';
 import {
   eot,
@@ -56,6 +79,9 @@ import {
   matchSet,
   matchWord,
   safeVariable,
+  setAZ,
+  setStringBoundary,
+  variable,
   walkWhitespace,
 } from './util';
 
@@ -84,8 +110,11 @@ export function parseProgram(src, i, c) {
   return opPipe(src, n, c);
 }
 
-/** Parse function header at i */
-export function parseFunctionHeader(src, i, c) {
+/**
+ * Parse function header at i.
+ * The typed arguments are only returned if at least one argument is annotated.
+ */
+export async function parseFunctionHeader(src, i, c) {
   let n = i;
 
   let m = matchWord(src, n, c, { phrase: '(' });
@@ -97,19 +126,18 @@ export function parseFunctionHeader(src, i, c) {
     });
 
   const argNames = [];
+  const args = [];
+  let typed = false;
   m = matchWord(src, n, c, { phrase: ')' });
   if (m.is) ({ i: n } = m);
   else
     for (;;) {
-      const v = safeVariable(src, n, c);
-      if (!v.is)
-        return errorUnexpectedToken(src, n, c, {
-          operator: 'function definition',
-          message: 'expected argument name',
-        });
-      let name;
-      ({ i: n, value: name } = v);
-      argNames.push(name);
+      let arg;
+      let isA;
+      ({ i: n, is: isA, arg } = await parseFunctionArgument(src, n, c));
+      argNames.push(arg.name);
+      args.push(arg);
+      typed ||= isA;
 
       m = matchWord(src, n, c, { phrase: ')' });
       if (m.is) {
@@ -134,7 +162,82 @@ export function parseFunctionHeader(src, i, c) {
       message: "expected ':'",
     });
 
-  return { i: n, argNames };
+  return { i: n, argNames, args: typed ? args : undefined };
+}
+
+/**
+ * Parse function argument at i, which may be annotated with an optional marker, types and a default value.
+ * `is` reports whether the argument has any annotations.
+ */
+async function parseFunctionArgument(src, i, c) {
+  let n = i;
+  let is = false;
+
+  const v = safeVariable(src, n, c);
+  if (!v.is)
+    return errorUnexpectedToken(src, n, c, {
+      operator: 'function definition',
+      message: 'expected argument name',
+    });
+  let name;
+  ({ i: n, value: name } = v);
+  const arg = { name };
+
+  let m = matchWord(src, n, c, { phrase: '?' });
+  if (m.is) {
+    ({ i: n } = m);
+    arg.optional = true;
+    is = true;
+  }
+
+  m = matchWord(src, n, c, { phrase: ':' });
+  if (m.is) {
+    ({ i: n } = m);
+    is = true;
+
+    arg.types = [];
+    for (;;) {
+      // Types are not variables, so reserved words like `null` are valid here
+      const t = variable(src, n, c);
+      if (!t.is)
+        return errorUnexpectedToken(src, n, c, {
+          operator: 'function definition',
+          message: 'expected argument type',
+        });
+
+      switch (t.value) {
+        case 'null':
+        case 'boolean':
+        case 'number':
+        case 'string':
+        case 'array':
+        case 'object':
+        case 'function':
+          break;
+
+        default:
+          return errorGeneric(src, n, c, {
+            operator: 'function definition',
+            message: `unknown type ${t.value}`,
+          });
+      }
+      ({ i: n } = t);
+      arg.types.push(t.value);
+
+      m = matchWord(src, n, c, { phrase: '|', notBeforeSet: '|=' });
+      if (!m.is) break;
+      ({ i: n } = m);
+    }
+  }
+
+  let ops;
+  ({ i: n, ops } = await parsePatternDefault(src, n, c));
+  if (ops) {
+    arg.default = ops;
+    is = true;
+  }
+
+  return { i: n, is, arg };
 }
 
 /** Parse access at i */
@@ -144,7 +247,47 @@ export async function parseAccess(src, i, c, { identity } = {}) {
   const selectors = [];
   let canAssign = true;
   for (;;) {
-    let m = matchWord(src, n, c, { phrase: '.' });
+    let m = matchWord(src, n, c, { phrase: '..', notBeforeSet: '.' });
+    if (m.is) {
+      ({ i: n } = m);
+
+      let opsField;
+      const v = safeVariable(src, n, c);
+      if (v.is) {
+        let name;
+        ({ i: n, value: name } = v);
+        opsField = [{ op: OP_STRING, params: { string: name } }];
+      } else {
+        m = matchWord(src, n, c, { phrase: '[' });
+        if (!m.is)
+          return errorUnexpectedToken(src, n, c, {
+            operator: 'recursive descent operator',
+            message: "expected field name or '['",
+          });
+        ({ i: n } = m);
+
+        m = matchWord(src, n, c, { phrase: ']' });
+        if (!m.is) {
+          ({ i: n, ops: opsField } = await opPipe(src, n, c));
+
+          m = matchWord(src, n, c, { phrase: ']' });
+          ({ i: n } = m);
+          if (!m.is)
+            return errorUnexpectedToken(src, n, c, {
+              operator: 'recursive descent operator',
+              message: "expected ']'",
+            });
+        } else ({ i: n } = m);
+      }
+
+      let optional;
+      m = matchWord(src, n, c, { phrase: '?', notBeforeSet: '?=' });
+      if (m.is) ({ i: n, is: optional } = m);
+      selectors.push({ op: OPA_RECURSIVE_DESCENT, params: { pipe: opsField, optional } });
+      continue;
+    }
+
+    m = matchWord(src, n, c, { phrase: '.' });
     const isIdentity = identity && selectors.length === 0;
     if (!isIdentity && m.is) {
       ({ i: n } = m);
@@ -368,43 +511,450 @@ export async function parseAssignment(src, i, c) {
     return { i: n, is: true, assignment: { op: OPU_SUBTRACTION, params: { pipe: ops } } };
   }
 
-  m = matchWord(src, n, c, { phrase: '*=' });
-  if (m.is) {
-    ({ i: n } = m);
+  m = matchWord(src, n, c, { phrase: '*=' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    let ops;
+    ({ i: n, ops } = await opSubRoute(src, n, c));
+    return { i: n, is: true, assignment: { op: OPU_MULTIPLICATION, params: { pipe: ops } } };
+  }
+
+  m = matchWord(src, n, c, { phrase: '/=' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    let ops;
+    ({ i: n, ops } = await opSubRoute(src, n, c));
+    return { i: n, is: true, assignment: { op: OPU_DIVISION, params: { pipe: ops } } };
+  }
+
+  m = matchWord(src, n, c, { phrase: '%=' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    let ops;
+    ({ i: n, ops } = await opSubRoute(src, n, c));
+    return { i: n, is: true, assignment: { op: OPU_REMAINDER, params: { pipe: ops } } };
+  }
+
+  m = matchWord(src, n, c, { phrase: '?=' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    let ops;
+    ({ i: n, ops } = await opSubRoute(src, n, c));
+    return { i: n, is: true, assignment: { op: OPU_NULL_COALESCENCE, params: { pipe: ops } } };
+  }
+
+  return { i: n, is: false };
+}
+
+/** Parse pattern at i, which is used for destructuring values */
+export async function parsePattern(src, i, c) {
+  let n = i;
+
+  let m = matchWord(src, n, c, { phrase: 'true', spaceAfter: true });
+  if (m.is) {
+    ({ i: n } = m);
+
+    return {
+      i: n,
+      is: true,
+      pattern: { op: OPD_VALUE, params: { pipe: [{ op: OP_CONSTANT_TRUE }] } },
+    };
+  }
+
+  m = matchWord(src, n, c, { phrase: 'false', spaceAfter: true });
+  if (m.is) {
+    ({ i: n } = m);
+
+    return {
+      i: n,
+      is: true,
+      pattern: { op: OPD_VALUE, params: { pipe: [{ op: OP_CONSTANT_FALSE }] } },
+    };
+  }
+
+  m = matchWord(src, n, c, { phrase: 'null', spaceAfter: true });
+  if (m.is) {
+    ({ i: n } = m);
+
+    return {
+      i: n,
+      is: true,
+      pattern: { op: OPD_VALUE, params: { pipe: [{ op: OP_CONSTANT_NULL }] } },
+    };
+  }
+
+  m = matchWord(src, n, c, { phrase: '-' });
+  const num = parseNumber(src, m.i, c);
+  if (num.is) {
+    ({ i: n } = num);
+
+    const ops = m.is ? [...num.ops, { op: OP_NEGATION }] : num.ops;
+    return { i: n, is: true, pattern: { op: OPD_VALUE, params: { pipe: ops } } };
+  }
+
+  const s = await parseString(src, n, c);
+  if (s.is) {
+    ({ i: n } = s);
+
+    return { i: n, is: true, pattern: { op: OPD_VALUE, params: { pipe: s.ops } } };
+  }
+
+  m = matchWord(src, n, c, { phrase: '(' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    let ops;
+    ({ i: n, ops } = await opPipe(src, n, c));
+
+    m = matchWord(src, n, c, { phrase: ')' });
+    ({ i: n } = m);
+    if (!m.is)
+      return errorUnexpectedToken(src, n, c, { operator: 'pattern', message: "expected ')'" });
+
+    return { i: n, is: true, pattern: { op: OPD_VALUE, params: { pipe: ops } } };
+  }
+
+  const v = safeVariable(src, n, c);
+  if (v.is) {
+    let name;
+    ({ i: n, value: name } = v);
+
+    m = matchWord(src, n, c, { phrase: '(' });
+    if (!m.is) return { i: n, is: true, pattern: { op: OPD_VARIABLE, params: { name } } };
+
+    switch (name) {
+      case 'boolean':
+      case 'number':
+      case 'string':
+      case 'array':
+      case 'object':
+      case 'function':
+        break;
+
+      default:
+        return errorGeneric(src, n, c, { operator: 'pattern', message: `unknown type ${name}` });
+    }
+    ({ i: n } = m);
+
+    const p = await parsePattern(src, n, c);
+    ({ i: n } = p);
+
+    m = matchWord(src, n, c, { phrase: ')' });
+    ({ i: n } = m);
+    if (!m.is)
+      return errorUnexpectedToken(src, n, c, { operator: 'pattern', message: "expected ')'" });
+
+    return {
+      i: n,
+      is: true,
+      pattern: { op: OPD_TYPE, params: { type: name, pattern: p.is ? p.pattern : undefined } },
+    };
+  }
+
+  m = matchWord(src, n, c, { phrase: '{' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    const entries = [];
+    let rest;
+
+    m = matchWord(src, n, c, { phrase: '}' });
+    if (m.is) ({ i: n } = m);
+    else
+      for (;;) {
+        m = matchWord(src, n, c, { phrase: '...' });
+        if (m.is) {
+          ({ i: n } = m);
+
+          ({ i: n, rest } = parsePatternRest(src, n, c, '}'));
+          break;
+        }
+
+        const entry = {};
+        let shorthand;
+
+        m = matchWord(src, n, c, { phrase: '(' });
+        if (m.is) {
+          ({ i: n } = m);
+
+          ({ i: n, ops: entry.key } = await opPipe(src, n, c));
+
+          m = matchWord(src, n, c, { phrase: ')' });
+          ({ i: n } = m);
+          if (!m.is) {
+            return errorUnexpectedToken(src, n, c, {
+              operator: 'pattern',
+              message: "expected ')'",
+            });
+          }
+        } else {
+          const s = await parseString(src, n, c);
+          if (s.is) ({ i: n, ops: entry.key } = s);
+          else {
+            const vKey = safeVariable(src, n, c);
+            if (!vKey.is) {
+              return errorUnexpectedToken(src, n, c, {
+                operator: 'pattern',
+                message: 'expected field',
+              });
+            }
+            ({ i: n, value: shorthand } = vKey);
+
+            entry.key = [{ op: OP_STRING, params: { string: shorthand } }];
+          }
+        }
+
+        ({ i: n, is: entry.optional } = matchWord(src, n, c, { phrase: '?' }));
+
+        m = matchWord(src, n, c, { phrase: ':' });
+        if (m.is) {
+          ({ i: n } = m);
+
+          const p = await parsePattern(src, n, c);
+          ({ i: n } = p);
+          if (!p.is) {
+            return errorUnexpectedToken(src, n, c, {
+              operator: 'pattern',
+              message: 'expected pattern',
+            });
+          }
+          entry.pattern = p.pattern;
+        } else if (shorthand !== undefined) {
+          entry.pattern = { op: OPD_VARIABLE, params: { name: shorthand } };
+        } else {
+          return errorUnexpectedToken(src, n, c, {
+            operator: 'pattern',
+            message: "expected ':'",
+          });
+        }
+
+        ({ i: n, ops: entry.default } = await parsePatternDefault(src, n, c));
+
+        entries.push(entry);
+
+        m = matchWord(src, n, c, { phrase: '}' });
+        if (m.is) {
+          ({ i: n } = m);
+          break;
+        }
+
+        m = matchWord(src, n, c, { phrase: ',' });
+        ({ i: n } = m);
+        if (!m.is) {
+          return errorUnexpectedToken(src, n, c, {
+            operator: 'pattern',
+            message: "expected ',' or '}'",
+          });
+        }
+      }
+
+    return { i: n, is: true, pattern: { op: OPD_OBJECT, params: { entries, rest } } };
+  }
+
+  m = matchWord(src, n, c, { phrase: '[' });
+  if (m.is) {
+    ({ i: n } = m);
+
+    const entries = [];
+    let rest;
+
+    m = matchWord(src, n, c, { phrase: ']' });
+    if (m.is) ({ i: n } = m);
+    else
+      for (;;) {
+        m = matchWord(src, n, c, { phrase: '...' });
+        if (m.is) {
+          ({ i: n } = m);
+
+          ({ i: n, rest } = parsePatternRest(src, n, c, ']'));
+          break;
+        }
+
+        const entry = {};
+
+        const p = await parsePattern(src, n, c);
+        ({ i: n } = p);
+        if (!p.is) {
+          return errorUnexpectedToken(src, n, c, {
+            operator: 'pattern',
+            message: 'expected pattern',
+          });
+        }
+        entry.pattern = p.pattern;
+
+        ({ i: n, is: entry.optional } = matchWord(src, n, c, { phrase: '?' }));
+
+        ({ i: n, ops: entry.default } = await parsePatternDefault(src, n, c));
+
+        entries.push(entry);
+
+        m = matchWord(src, n, c, { phrase: ']' });
+        if (m.is) {
+          ({ i: n } = m);
+          break;
+        }
+
+        m = matchWord(src, n, c, { phrase: ',' });
+        ({ i: n } = m);
+        if (!m.is) {
+          return errorUnexpectedToken(src, n, c, {
+            operator: 'pattern',
+            message: "expected ',' or ']'",
+          });
+        }
+      }
+
+    return { i: n, is: true, pattern: { op: OPD_ARRAY, params: { entries, rest } } };
+  }
+
+  return { i, is: false };
+}
+
+/** Parse the name of a pattern's rest at i, which must be followed by end */
+function parsePatternRest(src, i, c, end) {
+  let n = i;
+
+  const v = safeVariable(src, n, c);
+  if (!v.is) {
+    return errorUnexpectedToken(src, n, c, {
+      operator: 'pattern',
+      message: 'expected variable name',
+    });
+  }
+  let name;
+  ({ i: n, value: name } = v);
+
+  const m = matchWord(src, n, c, { phrase: end });
+  ({ i: n } = m);
+  if (!m.is) {
+    return errorUnexpectedToken(src, n, c, {
+      operator: 'pattern',
+      message: `expected '${end}' after rest`,
+    });
+  }
+
+  return { i: n, rest: name };
+}
+
+/** Parse the default value of a pattern entry at i, if present */
+async function parsePatternDefault(src, i, c) {
+  let n = i;
+
+  const m = matchWord(src, n, c, { phrase: '=', notBeforeSet: '=' });
+  if (!m.is) return { i: n };
+  ({ i: n } = m);
+
+  return opSubPipe(src, n, c);
+}
+
+/**
+ * Parse destructuring definition at i, which is a pattern followed by an assignment.
+ * Other constructs starting like a pattern, e.g. object constructors, are not reported unless they are followed by an assignment.
+ */
+export async function parseDestructuringDefinition(src, i, c) {
+  let n = i;
+
+  let p;
+  try {
+    p = await parsePattern(src, n, c);
+  } catch (err) {
+    if (!(err instanceof JPLSyntaxError)) throw err;
+
+    // The construct may still be a valid value, e.g. an array constructor containing spread operands.
+    // The pattern's error is only reported if that value is followed by an assignment.
+    let v;
+    try {
+      v = await opObjectConstructor(src, n, c);
+    } catch (errV) {
+      if (errV instanceof JPLSyntaxError) return { i, is: false };
+      throw errV;
+    }
+    if (matchWord(src, v.i, c, { phrase: '=', notBeforeSet: '=' }).is) throw err;
+    return { i, is: false };
+  }
+  if (!p.is || ![OPD_OBJECT, OPD_ARRAY].includes(p.pattern.op)) return { i, is: false };
+  ({ i: n } = p);
+
+  const m = matchWord(src, n, c, { phrase: '=', notBeforeSet: '=' });
+  if (!m.is) return { i, is: false };
+  ({ i: n } = m);
+
+  let ops;
+  ({ i: n, ops } = await opSubRoute(src, n, c));
+
+  return {
+    i: n,
+    is: true,
+    ops: [{ op: OP_DESTRUCTURING_DEFINITION, params: { pattern: p.pattern, pipe: ops } }],
+  };
+}
+
+/**
+ * Parse label header at i, which is `label name |`.
+ * `label` is not reserved, so it is only considered to be a label if it is followed by a name and a pipe.
+ */
+export function parseLabel(src, i, c) {
+  let n = i;
+
+  let m = matchWord(src, n, c, { phrase: 'label', spaceAfter: true });
+  if (!m.is) return { i, is: false };
+  ({ i: n } = m);
+
+  const v = safeVariable(src, n, c);
+  if (!v.is) return { i, is: false };
+  ({ i: n } = v);
+
+  m = matchWord(src, n, c, { phrase: '|', notBeforeSet: '=' });
+  if (!m.is) return { i, is: false };
+  ({ i: n } = m);
+
+  return { i: n, is: true, name: v.value };
+}
+
+/** Parse path at i, which is a value access that could also be used as an assignment target */
+export async function parsePath(src, i, c, operator) {
+  let n = i;
 
-    let ops;
-    ({ i: n, ops } = await opSubRoute(src, n, c));
-    return { i: n, is: true, assignment: { op: OPU_MULTIPLICATION, params: { pipe: ops } } };
-  }
+  const selectors = [];
 
-  m = matchWord(src, n, c, { phrase: '/=' });
-  if (m.is) {
-    ({ i: n } = m);
+  let m = matchWord(src, n, c, { phrase: '.', notBeforeSet: '.' });
+  const isDot = m.is;
+  if (isDot) ({ i: n } = m);
+  // Recursive descents are parsed as part of the access
+  else if (!matchWord(src, n, c, { phrase: '..', notBeforeSet: '.' }).is)
+    return errorUnexpectedToken(src, m.i, c, { operator, message: 'expected path' });
 
-    let ops;
-    ({ i: n, ops } = await opSubRoute(src, n, c));
-    return { i: n, is: true, assignment: { op: OPU_DIVISION, params: { pipe: ops } } };
-  }
+  const v = safeVariable(src, n, c);
+  if (isDot && v.is) {
+    let name;
+    ({ i: n, value: name } = v);
 
-  m = matchWord(src, n, c, { phrase: '%=' });
-  if (m.is) {
-    ({ i: n } = m);
+    let optional;
+    m = matchWord(src, n, c, { phrase: '?', notBeforeSet: '?=' });
+    if (m.is) ({ i: n, is: optional } = m);
 
-    let ops;
-    ({ i: n, ops } = await opSubRoute(src, n, c));
-    return { i: n, is: true, assignment: { op: OPU_REMAINDER, params: { pipe: ops } } };
+    selectors.push({
+      op: OPA_FIELD,
+      params: { pipe: [{ op: OP_STRING, params: { string: name } }], optional },
+    });
   }
 
-  m = matchWord(src, n, c, { phrase: '?=' });
-  if (m.is) {
-    ({ i: n } = m);
-
-    let ops;
-    ({ i: n, ops } = await opSubRoute(src, n, c));
-    return { i: n, is: true, assignment: { op: OPU_NULL_COALESCENCE, params: { pipe: ops } } };
+  const ac = await parseAccess(src, n, c, { identity: selectors.length === 0 });
+  if (ac.is) {
+    if (!ac.canAssign)
+      return errorUnexpectedToken(src, ac.i, c, {
+        operator,
+        message: 'function calls cannot be part of a path',
+      });
+    ({ i: n } = ac);
+    selectors.push(...ac.selectors);
   }
 
-  return { i: n, is: false };
+  return { i: n, selectors };
 }
 
 /** Parse number at i */
@@ -474,8 +1024,13 @@ export async function parseString(src, i, c) {
   let n = i;
   let value = '';
 
-  const set = matchSet(src, n, c, { set: '"\'`' });
-  if (!set.is) return { i: n, is: false };
+  // Raw strings do not support escape sequences or interpolations
+  let raw = false;
+  let m = match(src, n, c, { phrase: 'r' });
+  if (m.is && matchSet(src, m.i, c, { set: setStringBoundary }).is) ({ i: n, is: raw } = m);
+
+  const set = matchSet(src, n, c, { set: setStringBoundary });
+  if (!set.is) return { i, is: false };
   let boundary;
   ({ i: n, value: boundary } = set);
 
@@ -483,7 +1038,7 @@ export async function parseString(src, i, c) {
 
   const interpolations = [];
   for (;;) {
-    let m = match(src, n, c, { phrase: boundary });
+    m = match(src, n, c, { phrase: boundary });
     if (m.is) {
       ({ i: n } = m);
       break;
@@ -499,7 +1054,7 @@ export async function parseString(src, i, c) {
     }
 
     m = match(src, n, c, { phrase: '\\' });
-    if (m.is) {
+    if (m.is && !raw) {
       ({ i: n } = m);
 
       m = matchWord(src, n, c, { phrase: '(' });
@@ -643,6 +1198,93 @@ export async function parseString(src, i, c) {
   };
 }
 
+/** Parse regular expression at i */
+export function parseRegex(src, i, c) {
+  let n = i;
+  let pattern = '';
+
+  const m = match(src, n, c, { phrase: '/' });
+  if (!m.is) return { i: n, is: false };
+  ({ i: n } = m);
+
+  // Slashes inside of character classes do not need to be escaped
+  let inClass = false;
+  for (;;) {
+    let end = eot(src, n, c);
+    if (end.is) {
+      ({ i: n } = end);
+      return errorUnexpectedToken(src, n, c, {
+        operator: 'regular expression',
+        message: 'incomplete regular expression literal',
+      });
+    }
+
+    if (src.charCodeAt(n) < 0x20) {
+      return errorUnexpectedToken(src, n, c, { operator: 'regular expression' });
+    }
+
+    if (src[n] === '/' && !inClass) {
+      n += 1;
+      break;
+    }
+
+    if (src[n] === '\\') {
+      n += 1;
+      end = eot(src, n, c);
+      if (end.is) {
+        ({ i: n } = end);
+        return errorUnexpectedToken(src, n, c, {
+          operator: 'regular expression',
+          message: 'incomplete regular expression literal',
+        });
+      }
+      if (src.charCodeAt(n) < 0x20) {
+        return errorUnexpectedToken(src, n, c, { operator: 'regular expression' });
+      }
+
+      // Escaped slashes are only escaped for the literal and not for the expression itself
+      if (src[n] !== '/') pattern += '\\';
+      pattern += src[n];
+      n += 1;
+      continue;
+    }
+
+    switch (src[n]) {
+      case '[':
+        inClass = true;
+        break;
+
+      case ']':
+        inClass = false;
+        break;
+
+      default:
+    }
+
+    pattern += src[n];
+    n += 1;
+  }
+
+  let flags = '';
+  for (;;) {
+    const set = matchSet(src, n, c, { set: setAZ });
+    if (!set.is) break;
+    ({ i: n } = set);
+    flags += set.value;
+  }
+
+  try {
+    compileRegex(pattern, flags);
+  } catch (err) {
+    if (!JPLRuntimeError.is(err)) throw err;
+    return errorGeneric(src, i, c, { operator: 'regular expression', message: err.message });
+  }
+
+  ({ i: n } = walkWhitespace(src, n, c));
+
+  return { i: n, is: true, ops: [{ op: OP_REGEX, params: { string: pattern, flags } }] };
+}
+
 /** Parse pipe at i */
 export async function opPipe(src, i, c) {
   // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
@@ -652,6 +1294,14 @@ export async function opPipe(src, i, c) {
 
   const pipe = [];
   for (;;) {
+    const l = parseLabel(src, n, c);
+    if (l.is) {
+      let ops;
+      ({ i: n, ops } = await opPipe(src, l.i, c));
+      pipe.push({ op: OP_LABEL, params: { name: l.name, pipe: ops } });
+      break;
+    }
+
     let ops;
     ({ i: n, ops } = await opOutputConcat(src, n, c));
     pipe.push(...ops);
@@ -673,6 +1323,14 @@ export async function opSubPipe(src, i, c) {
 
   const pipe = [];
   for (;;) {
+    const l = parseLabel(src, n, c);
+    if (l.is) {
+      let ops;
+      ({ i: n, ops } = await opSubPipe(src, l.i, c));
+      pipe.push({ op: OP_LABEL, params: { name: l.name, pipe: ops } });
+      break;
+    }
+
     let ops;
     ({ i: n, ops } = await opTry(src, n, c));
     pipe.push(...ops);
@@ -697,10 +1355,17 @@ export async function opSubRoute(src, i, c) {
 export async function opOutputConcat(src, i, c) {
   let n = i;
 
+  // Operands are only spread directly, so nested contexts do not allow spreading
+  const operandContext = c.spread ? { ...c, spread: false } : c;
+
   const pipes = [];
   for (;;) {
+    const spread = c.spread ? matchWord(src, n, c, { phrase: '...' }) : { is: false };
+    if (spread.is) ({ i: n } = spread);
+
     let ops;
-    ({ i: n, ops } = await opTry(src, n, c));
+    ({ i: n, ops } = await opTry(src, n, operandContext));
+    if (spread.is) ops = [{ op: OP_SPREAD, params: { pipe: ops } }];
     pipes.push(ops);
 
     const m = matchWord(src, n, c, { phrase: ',' });
@@ -724,15 +1389,99 @@ export async function opTry(src, i, c) {
   let opsTry;
   ({ i: n, ops: opsTry } = await opOr(src, n, c));
 
+  const catches = [];
   let opsCatch;
-  m = matchWord(src, n, c, { spaceBefore: true, phrase: 'catch', spaceAfter: true });
-  if (m.is) {
+  for (;;) {
+    m = matchWord(src, n, c, { spaceBefore: true, phrase: 'catch', spaceAfter: true });
+    if (!m.is) break;
+    ({ i: n } = m);
+
+    const h = await parseCatchHead(src, n, c);
+    if (!h.is) {
+      // A catch clause without a head catches all remaining errors and must therefore be the last one
+      ({ i: n, ops: opsCatch } = await opOr(src, n, c));
+      break;
+    }
+    ({ i: n } = h);
+
+    let ops;
+    ({ i: n, ops } = await opOr(src, n, c));
+    catches.push({ ...h.clause, pipe: ops });
+  }
+
+  if (catches.length === 0) {
+    return {
+      i: n,
+      ops: [{ op: OP_TRY, params: { try: opsTry, catch: opsCatch ?? [{ op: OP_VOID }] } }],
+    };
+  }
+
+  if (opsCatch) catches.push({ raw: true, pipe: opsCatch });
+  return { i: n, ops: [{ op: OP_TRY, params: { try: opsTry, catches } }] };
+}
+
+/**
+ * Parse catch clause head at i, which consists of error kinds and an optional condition, followed by ':'.
+ * Other constructs, e.g. the expression of a catch-all clause, are not reported.
+ */
+async function parseCatchHead(src, i, c) {
+  let n = i;
+  const clause = {};
+
+  const kinds = [];
+  const positions = [];
+  for (;;) {
+    const v = variable(src, n, c);
+    if (!v.is || v.value === 'when') {
+      if (kinds.length > 0) return { i, is: false };
+      break;
+    }
+    positions.push(n);
+    kinds.push(v.value);
+    ({ i: n } = v);
+
+    const m = matchWord(src, n, c, { phrase: '|', notBeforeSet: '=' });
+    if (!m.is) break;
     ({ i: n } = m);
+  }
+  if (kinds.length > 0) clause.kinds = kinds;
+
+  let m = matchWord(src, n, c, { phrase: 'when', spaceAfter: true });
+  if (m.is) {
+    try {
+      ({ i: n, ops: clause.when } = await opOr(src, m.i, c));
+    } catch (err) {
+      if (err instanceof JPLSyntaxError) return { i, is: false };
+      throw err;
+    }
+  }
+
+  if (!clause.kinds && !clause.when) return { i, is: false };
+
+  m = matchWord(src, n, c, { phrase: ':' });
+  if (!m.is) return { i, is: false };
+  ({ i: n } = m);
 
-    ({ i: n, ops: opsCatch } = await opOr(src, n, c));
-  } else opsCatch = [{ op: OP_VOID }];
+  for (const [j, kind] of kinds.entries()) {
+    switch (kind) {
+      case ERROR_KIND_ERROR:
+      case ERROR_KIND_RUNTIME_ERROR:
+      case ERROR_KIND_TYPE_ERROR:
+      case ERROR_KIND_REFERENCE_ERROR:
+      case ERROR_KIND_ZERO_DIVISION_ERROR:
+      case ERROR_KIND_TYPE_CONVERSION_ERROR:
+      case ERROR_KIND_EXECUTION_ERROR:
+        break;
+
+      default:
+        return errorGeneric(src, positions[j], c, {
+          operator: 'catch clause',
+          message: `unknown error kind ${kind}`,
+        });
+    }
+  }
 
-  return { i: n, ops: [{ op: OP_TRY, params: { try: opsTry, catch: opsCatch } }] };
+  return { i: n, is: true, clause };
 }
 
 /** Parse or at i */
@@ -1023,7 +1772,7 @@ export async function opIf(src, i, c) {
   let n = i;
 
   let m = matchWord(src, n, c, { phrase: 'if', spaceAfter: true });
-  if (!m.is) return opConstant(src, n, c);
+  if (!m.is) return opMatch(src, n, c);
   ({ i: n } = m);
 
   const ifs = [];
@@ -1067,6 +1816,134 @@ export async function opIf(src, i, c) {
   return { i: n, ops: [{ op: OP_IF, params: { ifs, else: opsElse } }] };
 }
 
+/** Parse match statement at i */
+export async function opMatch(src, i, c) {
+  let n = i;
+
+  let m = matchWord(src, n, c, { phrase: 'match', spaceAfter: true });
+  if (!m.is) return opDelete(src, n, c);
+
+  // `match` is not reserved, so it is only considered to be a statement if it is followed by a subject and `with`.
+  // In the subject, `with` is reserved, so that e.g. `. with` is not considered to be a field access.
+  let subject;
+  try {
+    subject = await opPipe(src, m.i, { ...c, reserved: [...(c.reserved ?? []), 'with'] });
+  } catch (err) {
+    if (err instanceof JPLSyntaxError) return opDelete(src, n, c);
+    throw err;
+  }
+  m = matchWord(src, subject.i, c, { spaceBefore: true, phrase: 'with', spaceAfter: true });
+  if (!m.is) return opDelete(src, n, c);
+  ({ i: n } = m);
+
+  const cases = [];
+  for (;;) {
+    const p = await parsePattern(src, n, c);
+    ({ i: n } = p);
+    if (!p.is)
+      return errorUnexpectedToken(src, n, c, {
+        operator: 'match statement',
+        message: 'expected pattern',
+      });
+
+    let opsIf;
+    m = matchWord(src, n, c, { spaceBefore: true, phrase: 'if', spaceAfter: true });
+    if (m.is) {
+      ({ i: n } = m);
+
+      ({ i: n, ops: opsIf } = await opPipe(src, n, c));
+    }
+
+    m = matchWord(src, n, c, { spaceBefore: true, phrase: 'then', spaceAfter: true });
+    ({ i: n } = m);
+    if (!m.is)
+      return errorUnexpectedToken(src, n, c, {
+        operator: 'match statement',
+        message: "expected 'then'",
+      });
+
+    let opsThen;
+    ({ i: n, ops: opsThen } = await opPipe(src, n, c));
+    cases.push({ pattern: p.pattern, if: opsIf, then: opsThen });
+
+    m = matchWord(src, n, c, { spaceBefore: true, phrase: 'with', spaceAfter: true });
+    if (!m.is) break;
+    ({ i: n } = m);
+  }
+
+  let opsElse;
+  m = matchWord(src, n, c, { spaceBefore: true, phrase: 'else', spaceAfter: true });
+  if (m.is) {
+    ({ i: n } = m);
+
+    ({ i: n, ops: opsElse } = await opPipe(src, n, c));
+  }
+
+  m = matchWord(src, n, c, { spaceBefore: true, phrase: 'end' });
+  ({ i: n } = m);
+  if (!m.is)
+    return errorUnexpectedToken(src, n, c, {
+      operator: 'match statement',
+      message: "expected 'end'",
+    });
+
+  return {
+    i: n,
+    ops: [{ op: OP_MATCH, params: { pipe: subject.ops, cases, else: opsElse } }],
+  };
+}
+
+/** Parse delete statement at i */
+export async function opDelete(src, i, c) {
+  let n = i;
+
+  let m = matchWord(src, n, c, { phrase: 'delete', spaceAfter: true });
+  if (!m.is) return opBreak(src, n, c);
+
+  // `delete` is not reserved, so it is only considered to be a statement if it is followed by a group
+  m = matchWord(src, m.i, c, { phrase: '(' });
+  if (!m.is) return opBreak(src, n, c);
+  ({ i: n } = m);
+
+  const paths = [];
+  for (;;) {
+    let selectors;
+    ({ i: n, selectors } = await parsePath(src, n, c, 'delete statement'));
+    paths.push(selectors);
+
+    m = matchWord(src, n, c, { phrase: ')' });
+    if (m.is) {
+      ({ i: n } = m);
+      break;
+    }
+
+    m = matchWord(src, n, c, { phrase: ',' });
+    ({ i: n } = m);
+    if (!m.is)
+      return errorUnexpectedToken(src, n, c, {
+        operator: 'delete statement',
+        message: "expected ',' or ')'",
+      });
+  }
+
+  return { i: n, ops: [{ op: OP_DELETE, params: { paths } }] };
+}
+
+/** Parse break statement at i */
+export function opBreak(src, i, c) {
+  let n = i;
+
+  const m = matchWord(src, n, c, { phrase: 'break', spaceAfter: true });
+  if (!m.is) return opConstant(src, n, c);
+
+  // `break` is not reserved, so it is only considered to be a statement if it is followed by a label name
+  const v = safeVariable(src, m.i, c);
+  if (!v.is) return opConstant(src, n, c);
+  ({ i: n } = v);
+
+  return { i: n, ops: [{ op: OP_BREAK, params: { name: v.value } }] };
+}
+
 /** Parse constant at i */
 export function opConstant(src, i, c) {
   let n = i;
@@ -1117,7 +1994,8 @@ export async function opNamedFunctionDefinition(src, i, c) {
   ({ i: n, value: name } = v);
 
   let argNames;
-  ({ i: n, argNames } = await parseFunctionHeader(src, n, c));
+  let args;
+  ({ i: n, argNames, args } = await parseFunctionHeader(src, n, c));
 
   let ops;
   ({ i: n, ops } = await opSubRoute(src, n, c));
@@ -1127,7 +2005,10 @@ export async function opNamedFunctionDefinition(src, i, c) {
     ops: [
       {
         op: OP_VARIABLE_DEFINITION,
-        params: { name, pipe: [{ op: OP_FUNCTION_DEFINITION, params: { argNames, pipe: ops } }] },
+        params: {
+          name,
+          pipe: [{ op: OP_FUNCTION_DEFINITION, params: { name, argNames, args, pipe: ops } }],
+        },
       },
     ],
   };
@@ -1138,16 +2019,31 @@ export async function opFunctionDefinition(src, i, c) {
   let n = i;
 
   const m = matchWord(src, n, c, { phrase: 'func', spaceAfter: true });
-  if (!m.is) return opVariableAccess(src, n, c);
+  if (!m.is) return opLambda(src, n, c);
   ({ i: n } = m);
 
   let argNames;
-  ({ i: n, argNames } = await parseFunctionHeader(src, n, c));
+  let args;
+  ({ i: n, argNames, args } = await parseFunctionHeader(src, n, c));
+
+  let ops;
+  ({ i: n, ops } = await opSubRoute(src, n, c));
+
+  return { i: n, ops: [{ op: OP_FUNCTION_DEFINITION, params: { argNames, args, pipe: ops } }] };
+}
+
+/** Parse lambda at i, which is a shorthand for a function definition without arguments */
+export async function opLambda(src, i, c) {
+  let n = i;
+
+  const m = matchWord(src, n, c, { phrase: '=>' });
+  if (!m.is) return opVariableAccess(src, n, c);
+  ({ i: n } = m);
 
   let ops;
   ({ i: n, ops } = await opSubRoute(src, n, c));
 
-  return { i: n, ops: [{ op: OP_FUNCTION_DEFINITION, params: { argNames, pipe: ops } }] };
+  return { i: n, ops: [{ op: OP_FUNCTION_DEFINITION, params: { argNames: [], pipe: ops } }] };
 }
 
 /** Parse variable definition at i */
@@ -1155,7 +2051,12 @@ export async function opVariableAccess(src, i, c) {
   let n = i;
 
   const v = safeVariable(src, n, c);
-  if (!v.is) return opValueAccess(src, n, c);
+  if (!v.is) {
+    const d = await parseDestructuringDefinition(src, n, c);
+    if (d.is) return { i: d.i, ops: d.ops };
+
+    return opValueAccess(src, n, c);
+  }
   let name;
   ({ i: n, value: name } = v);
 
@@ -1186,10 +2087,12 @@ export async function opVariableAccess(src, i, c) {
   ({ i: n, assignment: opAssignment } = as);
 
   if (selectors.length === 0 && opAssignment.op === OPU_SET) {
-    return {
-      i: n,
-      ops: [{ op: OP_VARIABLE_DEFINITION, params: { name, pipe: opAssignment.params.pipe } }],
-    };
+    let { pipe } = opAssignment.params;
+    // Functions that are directly assigned to a variable are named after it
+    if (pipe.length === 1 && pipe[0].op === OP_FUNCTION_DEFINITION && !pipe[0].params.name) {
+      pipe = [{ ...pipe[0], params: { ...pipe[0].params, name } }];
+    }
+    return { i: n, ops: [{ op: OP_VARIABLE_DEFINITION, params: { name, pipe } }] };
   }
 
   return {
@@ -1222,8 +2125,10 @@ export async function opValueAccess(src, i, c) {
   const selectors = [];
 
   let ops;
-  let m = matchWord(src, n, c, { phrase: '.' });
-  if (!m.is) ({ i: n, ops } = await opObjectConstructor(src, n, c));
+  let m = matchWord(src, n, c, { phrase: '.', notBeforeSet: '.' });
+  // Recursive descents are parsed as part of the access
+  if (!m.is && matchWord(src, n, c, { phrase: '..', notBeforeSet: '.' }).is) ops = [];
+  else if (!m.is) ({ i: n, ops } = await opObjectConstructor(src, n, c));
   else {
     ({ i: n } = m);
     ops = [];
@@ -1282,6 +2187,32 @@ export async function opObjectConstructor(src, i, c) {
   if (m.is) ({ i: n } = m);
   else
     for (;;) {
+      m = matchWord(src, n, c, { phrase: '...' });
+      if (m.is) {
+        ({ i: n } = m);
+
+        let opsValue;
+        ({ i: n, ops: opsValue } = await opSubPipe(src, n, c));
+
+        fields.push({ value: opsValue, spread: true });
+
+        m = matchWord(src, n, c, { phrase: '}' });
+        if (m.is) {
+          ({ i: n } = m);
+          break;
+        }
+
+        m = matchWord(src, n, c, { phrase: ',' });
+        ({ i: n } = m);
+        if (!m.is)
+          return errorUnexpectedToken(src, n, c, {
+            operator: 'object',
+            message: "expected ',' or '}'",
+          });
+
+        continue;
+      }
+
       m = matchWord(src, n, c, { phrase: '(' });
       if (m.is) {
         ({ i: n } = m);
@@ -1432,7 +2363,7 @@ export async function opArrayConstructor(src, i, c) {
 
     ops = [{ op: OP_VOID }];
   } else {
-    ({ i: n, ops } = await opPipe(src, n, c));
+    ({ i: n, ops } = await opPipe(src, n, { ...c, spread: true }));
 
     m = matchWord(src, n, c, { phrase: ']' });
     ({ i: n } = m);
@@ -1448,7 +2379,11 @@ export async function opStringLiteral(src, i, c) {
   let n = i;
 
   const s = await parseString(src, n, c);
-  if (!s.is) return opGroup(src, n, c);
+  if (!s.is) {
+    const r = parseRegex(src, n, c);
+    if (!r.is) return opGroup(src, n, c);
+    return { i: r.i, ops: r.ops };
+  }
   ({ i: n } = s);
 
   return { i: n, ops: s.ops };
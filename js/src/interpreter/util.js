@@ -1,12 +1,13 @@
 import { JPLSyntaxError } from '../library';
 
-const setAZ = 'abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ';
+export const setAZ = 'abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ';
 const setDigit = '0123456789';
 const setVarFirst = `${setAZ}_$`;
 const setVarRest = `${setVarFirst}${setDigit}`;
 const setVarAll = setVarRest;
 const setWhitespace = ' \xa0\r\n\t';
 const setHex = `${setDigit}abcdefABCDEF`;
+export const setStringBoundary = '"\'`';
 
 /** Walk whitespace at i */
 export function walkWhitespace(src, i, c) {
@@ -147,6 +148,11 @@ export function variable(src, i, c) {
     value += set.value;
   }
 
+  // `r` directly followed by a string boundary introduces a raw string
+  if (value === 'r' && matchSet(src, n, c, { set: setStringBoundary }).is) {
+    return { i, is: false, value: '' };
+  }
+
   if (is) ({ i: n } = walkWhitespace(src, n, c));
 
   return { i: n, is, value };
@@ -176,6 +182,7 @@ export function safeVariable(src, i, c) {
       return { i, is: false, value: v.value, reserved: true };
 
     default:
+      if (c.reserved?.includes(v.value)) return { i, is: false, value: v.value, reserved: true };
       return v;
   }
 }
@@ -1,3 +1,3 @@
 export const DEFINITION_VERSION_MAJOR = 1;
-export const DEFINITION_VERSION_MINOR = 0;
+export const DEFINITION_VERSION_MINOR = 1;
 export const DEFINITION_VERSION = `${DEFINITION_VERSION_MAJOR}.${DEFINITION_VERSION_MINOR}`;
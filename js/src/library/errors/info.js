@@ -0,0 +1,78 @@
+import { unwrap } from '../types';
+import JPLExecutionError from './execution';
+import JPLRuntimeError, {
+  JPLReferenceError,
+  JPLTypeConversionError,
+  JPLTypeError,
+  JPLUserError,
+  JPLZeroDivisionError,
+} from './runtime';
+
+export const ERROR_KIND_ERROR = 'Error';
+export const ERROR_KIND_RUNTIME_ERROR = 'RuntimeError';
+export const ERROR_KIND_TYPE_ERROR = 'TypeError';
+export const ERROR_KIND_REFERENCE_ERROR = 'ReferenceError';
+export const ERROR_KIND_ZERO_DIVISION_ERROR = 'ZeroDivisionError';
+export const ERROR_KIND_TYPE_CONVERSION_ERROR = 'TypeConversionError';
+export const ERROR_KIND_EXECUTION_ERROR = 'ExecutionError';
+
+const prefixedKinds = [
+  ERROR_KIND_TYPE_ERROR,
+  ERROR_KIND_REFERENCE_ERROR,
+  ERROR_KIND_ZERO_DIVISION_ERROR,
+  ERROR_KIND_TYPE_CONVERSION_ERROR,
+];
+
+/** Resolve the kind of the specified execution error */
+export function errorKind(err) {
+  if (JPLUserError.is(err)) return ERROR_KIND_ERROR;
+  if (JPLTypeError.is(err)) return ERROR_KIND_TYPE_ERROR;
+  if (JPLReferenceError.is(err)) return ERROR_KIND_REFERENCE_ERROR;
+  if (JPLZeroDivisionError.is(err)) return ERROR_KIND_ZERO_DIVISION_ERROR;
+  if (JPLTypeConversionError.is(err)) return ERROR_KIND_TYPE_CONVERSION_ERROR;
+  if (JPLRuntimeError.is(err)) return ERROR_KIND_RUNTIME_ERROR;
+  return ERROR_KIND_EXECUTION_ERROR;
+}
+
+/**
+ * Create the structured error value `{ kind, message, value }` for the specified execution error.
+ * The message does not include the kind prefix of runtime errors.
+ */
+export function errorInfo(err) {
+  const kind = errorKind(err);
+  let { message } = err;
+  if (prefixedKinds.includes(kind) && message.startsWith(`${kind} - `)) {
+    message = message.slice(kind.length + 3);
+  }
+  return { kind, message, value: err.value };
+}
+
+/**
+ * Recreate the execution error described by the specified structured error value, which must have been created using `errorInfo`.
+ * It returns null if the value does not describe an error.
+ */
+export function errorFromInfo(info) {
+  const fields = unwrap(info);
+  if (typeof fields !== 'object' || fields === null || Array.isArray(fields)) return null;
+  const { kind, value } = fields;
+  const message = typeof fields.message === 'string' ? fields.message : '';
+
+  switch (kind) {
+    case ERROR_KIND_ERROR:
+      return new JPLUserError(value ?? null);
+    case ERROR_KIND_RUNTIME_ERROR:
+      return new JPLRuntimeError(value ?? null);
+    case ERROR_KIND_TYPE_ERROR:
+      return new JPLTypeError('%s', message);
+    case ERROR_KIND_REFERENCE_ERROR:
+      return new JPLReferenceError('%s', message);
+    case ERROR_KIND_ZERO_DIVISION_ERROR:
+      return new JPLZeroDivisionError('%s', message);
+    case ERROR_KIND_TYPE_CONVERSION_ERROR:
+      return new JPLTypeConversionError('%s', message);
+    case ERROR_KIND_EXECUTION_ERROR:
+      return new JPLExecutionError(message);
+    default:
+      return null;
+  }
+}
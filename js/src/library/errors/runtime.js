@@ -69,3 +69,10 @@ export class JPLTypeConversionError extends JPLRuntimeError {
     super(`TypeConversionError - ${format(value, replacements)}`);
   }
 }
+
+/**
+ * JPL runtime error type for errors thrown by the program itself, e.g. using the `error` builtin.
+ *
+ * `value` can by of any type.
+ */
+export class JPLUserError extends JPLRuntimeError {}
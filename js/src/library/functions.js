@@ -1,3 +1,4 @@
+import { JPLTypeError } from './errors/runtime';
 import JPLRuntimeScope from './runtimeScope';
 
 function jplEnclosure(runtime, signal, next, input, ...args) {
@@ -14,6 +15,81 @@ function jplEnclosure(runtime, signal, next, input, ...args) {
   );
 }
 
+/** Describe the function for error messages */
+function describeFunction(name) {
+  return name ? `function ${name}` : 'anonymous function';
+}
+
+/** Assert that the specified value matches one of the types of the argument, if any */
+function assertArgType(runtime, name, arg, value) {
+  if (!arg.types?.length) return;
+
+  const v = runtime.unwrapValue(value);
+  const t = runtime.type(v);
+  if (arg.types.includes(t)) return;
+
+  throw new JPLTypeError(
+    '%s expects argument %s to be %s but got %s (%*<100v)',
+    describeFunction(name),
+    arg.name,
+    arg.types.join(' | '),
+    t,
+    v,
+  );
+}
+
+/**
+ * Bind the argument at i and all of its successors to vars, validating their types.
+ * Omitted arguments are replaced by their default values, which have access to the preceding arguments.
+ */
+function bindArgs(runtime, signal, enclosure, input, args, i, vars, next) {
+  const { name, args: signature, scope } = enclosure;
+  if (i >= signature.length) return next(vars);
+  const arg = signature[i];
+
+  const bind = (value) =>
+    bindArgs(runtime, signal, enclosure, input, args, i + 1, { ...vars, [arg.name]: value }, next);
+
+  if (i < args.length) {
+    assertArgType(runtime, name, arg, args[i]);
+    return bind(args[i]);
+  }
+
+  if (arg.default) {
+    return runtime.executeInstructions(
+      arg.default,
+      [input],
+      scope.next({ signal, vars }),
+      (output) => {
+        assertArgType(runtime, name, arg, output);
+        return bind(output);
+      },
+    );
+  }
+
+  if (!arg.optional) {
+    throw new JPLTypeError('%s is missing argument %s', describeFunction(name), arg.name);
+  }
+  return bind(null);
+}
+
+function jplTypedEnclosure(runtime, signal, next, input, ...args) {
+  const { name, args: signature, pipe, scope } = this;
+
+  if (args.length > signature.length) {
+    throw new JPLTypeError(
+      'too many arguments for %s: expected at most %v, got %v',
+      describeFunction(name),
+      signature.length,
+      args.length,
+    );
+  }
+
+  return bindArgs(runtime, signal, this, input, args, 0, {}, (vars) =>
+    runtime.executeInstructions(pipe, [input], scope.next({ signal, vars }), next),
+  );
+}
+
 /**
  * Create a scoped JPL function from the specified instructions.
  *
@@ -27,6 +103,21 @@ export function scopedFunction(argNames, instructions, scope) {
   return jplEnclosure.bind({ argNames, pipe: instructions, scope });
 }
 
+/**
+ * Create a scoped JPL function with a typed signature from the specified instructions.
+ *
+ * The number of arguments and their types are validated when the function is called.
+ * Omitted arguments are replaced by their default values, if any.
+ *
+ * @param {string} name Name of the function, which is only used for error messages and may be empty
+ * @param {{ name: string, types: string[]?, optional: boolean?, default: object[]? }[]} args Signature of the function
+ * @param {object[]} instructions Instructions to execute
+ * @param {JPLRuntimeScope} scope Runtime scope to bind the function to
+ */
+export function typedFunction(name, args, instructions, scope) {
+  return jplTypedEnclosure.bind({ name, args, pipe: instructions, scope });
+}
+
 /**
  * Create an orphan JPL function from the specified instructions.
  *
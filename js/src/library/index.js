@@ -9,22 +9,39 @@ export { default as JPLErrorEnclosure } from './errors/enclosure';
 export { default as JPLError } from './errors/error';
 export { default as JPLExecutionError } from './errors/execution';
 export { default as JPLFatalError } from './errors/fatal';
+export {
+  ERROR_KIND_ERROR,
+  ERROR_KIND_EXECUTION_ERROR,
+  ERROR_KIND_REFERENCE_ERROR,
+  ERROR_KIND_RUNTIME_ERROR,
+  ERROR_KIND_TYPE_CONVERSION_ERROR,
+  ERROR_KIND_TYPE_ERROR,
+  ERROR_KIND_ZERO_DIVISION_ERROR,
+  errorFromInfo,
+  errorInfo,
+  errorKind,
+} from './errors/info';
 export {
   JPLReferenceError,
   default as JPLRuntimeError,
   JPLTypeConversionError,
   JPLTypeError,
+  JPLUserError,
   JPLZeroDivisionError,
 } from './errors/runtime';
 export { default as JPLSyntaxError } from './errors/syntax';
-export { nativeFunction, orphanFunction, scopedFunction } from './functions';
-export { default as mux, muxAll, muxAsync, muxOne } from './mux';
+export { nativeFunction, orphanFunction, scopedFunction, typedFunction } from './functions';
+export { formatJSON } from './json';
+export { default as mux, muxAll, muxAllSequential, muxAsync, muxOne, muxSequential } from './mux';
 export * from './ops';
+export { deletePaths, getPath, setPath } from './paths';
+export { JPLRegex, REGEX_FLAGS, compileRegex, findMatches, resolveRegex } from './regex';
 export { default as JPLRuntimeScope } from './runtimeScope';
 export {
   JPLType,
   assertType,
   displayValue,
+  formatTemplate,
   jplJSONStripper,
   jplStripper,
   jplTypedStripper,
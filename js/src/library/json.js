@@ -0,0 +1,87 @@
+import { strip } from './types';
+
+/** Stringify the specified normalized value as JSON for usage in program outputs */
+export function formatJSON(value, options) {
+  const o = options?.canonical ? { canonical: true } : options ?? {};
+  return writeJSON(strip(value), o, '');
+}
+
+function writeJSON(value, options, indent) {
+  const gap = options.indent ?? '';
+  const inner = indent + gap;
+  const separator = gap ? `\n${inner}` : '';
+  const end = gap ? `\n${indent}` : '';
+
+  if (Array.isArray(value)) {
+    if (value.length === 0) return '[]';
+    const items = value.map((item) => writeJSON(item, options, inner));
+    return `[${separator}${items.join(`,${separator}`)}${end}]`;
+  }
+
+  switch (typeof value) {
+    case 'boolean':
+      return String(value);
+
+    case 'number':
+      // Like `JSON.stringify`, which is also required by RFC 8785
+      return Number.isFinite(value) ? String(value) : 'null';
+
+    case 'string':
+      return writeJSONString(value, options.asciiOnly);
+
+    case 'object': {
+      if (value === null) return 'null';
+      const keys = Object.keys(value);
+      if (keys.length === 0) return '{}';
+      // RFC 8785 sorts keys by their UTF-16 code units
+      if (options.canonical) keys.sort();
+      else if (options.sortKeys) keys.sort(compareCodePoints);
+      const colon = gap ? ': ' : ':';
+      const fields = keys.map((key) => {
+        const k = writeJSONString(key, options.asciiOnly);
+        return `${k}${colon}${writeJSON(value[key], options, inner)}`;
+      });
+      return `{${separator}${fields.join(`,${separator}`)}${end}}`;
+    }
+
+    default:
+      return 'null';
+  }
+}
+
+const escapes = {
+  '"': '\\"',
+  '\\': '\\\\',
+  '\b': '\\b',
+  '\f': '\\f',
+  '\n': '\\n',
+  '\r': '\\r',
+  '\t': '\\t',
+};
+
+function writeJSONString(s, asciiOnly) {
+  let result = '"';
+  for (const c of s) {
+    const cp = c.codePointAt(0);
+    if (escapes[c]) result += escapes[c];
+    // Lone surrogates are escaped like `JSON.stringify` does
+    else if (cp < 0x20 || (asciiOnly && cp > 0x7f) || (cp >= 0xd800 && cp <= 0xdfff)) {
+      for (let i = 0; i < c.length; i += 1) {
+        result += `\\u${c.charCodeAt(i).toString(16).padStart(4, '0')}`;
+      }
+    } else result += c;
+  }
+  return `${result}"`;
+}
+
+/** Compare the specified strings based on their unicode code points */
+function compareCodePoints(a, b) {
+  const ca = [...a];
+  const cb = [...b];
+  const min = Math.min(ca.length, cb.length);
+  for (let i = 0; i < min; i += 1) {
+    const order = ca[i].codePointAt(0) - cb[i].codePointAt(0);
+    if (order !== 0) return order;
+  }
+  return ca.length - cb.length;
+}
@@ -90,6 +90,21 @@ export async function muxAll(args, cb) {
   return mergeSegments(await muxAsync(args, cb));
 }
 
+/** Multiplex the specified array of arguments asynchronously one after another and return the results produced by the callbacks */
+export async function muxSequential(args, cb) {
+  const combinations = muxOne(args, (...combination) => combination);
+  const outputs = new Array(combinations.length);
+  for (let i = 0; i < combinations.length; i += 1) {
+    outputs[i] = await cb(...combinations[i]);
+  }
+  return outputs;
+}
+
+/** Multiplex the specified array of arguments asynchronously one after another and return a single array of all merged result arrays produced by the callbacks */
+export async function muxAllSequential(args, cb) {
+  return mergeSegments(await muxSequential(args, cb));
+}
+
 /** Create a single array from the specified array segments */
 export function mergeSegments(segments) {
   if (segments.length === 0) return segments;
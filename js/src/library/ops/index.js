@@ -1,5 +1,6 @@
 export * from './op';
 export * from './opa';
 export * from './opc';
+export * from './opd';
 export * from './opm';
 export * from './opu';
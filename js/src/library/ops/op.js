@@ -26,6 +26,13 @@ export const OP_ARRAY_CONSTRUCTOR = '[]';
  */
 export const OP_ASSIGNMENT = '$=';
 
+/**
+ * { name: string }
+ *
+ * { name: string }
+ */
+export const OP_BREAK = 'brk';
+
 /**
  * { pipe: function, operations: [opm] }
  *
@@ -69,9 +76,23 @@ export const OP_CONSTANT_NULL = 'nul';
 export const OP_CONSTANT_TRUE = 'tru';
 
 /**
- * { argNames: [string], pipe: function }
+ * { paths: [[opa]] }
  *
- * { argNames: [string], pipe: [op] }
+ * { paths: [[opa]] }
+ */
+export const OP_DELETE = 'del';
+
+/**
+ * { pattern: opd, pipe: function }
+ *
+ * { pattern: opd, pipe: [op] }
+ */
+export const OP_DESTRUCTURING_DEFINITION = 'ds=';
+
+/**
+ * { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: function }], pipe: function }
+ *
+ * { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: [op] }], pipe: [op] }
  */
 export const OP_FUNCTION_DEFINITION = 'fun';
 
@@ -89,6 +110,20 @@ export const OP_IF = 'if';
  */
 export const OP_INTERPOLATED_STRING = '"$"';
 
+/**
+ * { name: string, pipe: function }
+ *
+ * { name: string, pipe: [op] }
+ */
+export const OP_LABEL = 'lbl';
+
+/**
+ * { pipe: function, cases: [{ pattern: opd, if: function, then: function }], else: function }
+ *
+ * { pipe: [op], cases: [{ pattern: opd, if: [op], then: [op] }], else: [op] }
+ */
+export const OP_MATCH = 'mat';
+
 /**
  * {}
  *
@@ -118,9 +153,9 @@ export const OP_NULL_COALESCENCE = '??';
 export const OP_NUMBER = 'nbr';
 
 /**
- * { fields: [{ key: function, value: function, optional: boolean }] }
+ * { fields: [{ key: function, value: function, optional: boolean, spread: boolean }] }
  *
- * { fields: [{ key: [op], value: [op], optional: boolean }] }
+ * { fields: [{ key: [op], value: [op], optional: boolean, spread: boolean }] }
  */
 export const OP_OBJECT_CONSTRUCTOR = '{}';
 
@@ -138,6 +173,20 @@ export const OP_OR = 'or';
  */
 export const OP_OUTPUT_CONCAT = ',';
 
+/**
+ * { string: string, flags: string }
+ *
+ * { string: string, flags: string }
+ */
+export const OP_REGEX = '//';
+
+/**
+ * { pipe: function }
+ *
+ * { pipe: [op] }
+ */
+export const OP_SPREAD = '...';
+
 /**
  * { string: string }
  *
@@ -146,9 +195,9 @@ export const OP_OUTPUT_CONCAT = ',';
 export const OP_STRING = '""';
 
 /**
- * { try: function, catch: function }
+ * { try: function, catch: function } | { try: function, catches: [{ kinds: [string], when: function, raw: boolean, pipe: function }] }
  *
- * { try: [op], catch: [op] }
+ * { try: [op], catch: [op] } | { try: [op], catches: [{ kinds: [string], when: [op], raw: boolean, pipe: [op] }] }
  */
 export const OP_TRY = 'try';
 
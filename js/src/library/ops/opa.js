@@ -25,6 +25,15 @@ export const OPA_FUNCTION = '$()';
  */
 export const OPA_ITER = '$[]';
 
+/**
+ * Sub operator for OP_ACCESS
+ *
+ * { pipe: function | null, optional: boolean }
+ *
+ * { pipe: [op] | null, optional: boolean }
+ */
+export const OPA_RECURSIVE_DESCENT = '..';
+
 /**
  * Sub operator for OP_ACCESS
  *
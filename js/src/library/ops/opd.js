@@ -0,0 +1,44 @@
+/**
+ * Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+ *
+ * { entries: [{ pattern: opd, default: function, optional: boolean }], rest: string }
+ *
+ * { entries: [{ pattern: opd, default: [op], optional: boolean }], rest: string }
+ */
+export const OPD_ARRAY = '[]';
+
+/**
+ * Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+ *
+ * { entries: [{ key: function, pattern: opd, default: function, optional: boolean }], rest: string }
+ *
+ * { entries: [{ key: [op], pattern: opd, default: [op], optional: boolean }], rest: string }
+ */
+export const OPD_OBJECT = '{}';
+
+/**
+ * Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+ *
+ * { type: string, pattern: opd }
+ *
+ * { type: string, pattern: opd }
+ */
+export const OPD_TYPE = 'is';
+
+/**
+ * Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+ *
+ * { pipe: function }
+ *
+ * { pipe: [op] }
+ */
+export const OPD_VALUE = '==';
+
+/**
+ * Sub operator for OP_DESTRUCTURING_DEFINITION and OP_MATCH
+ *
+ * { name: string }
+ *
+ * { name: string }
+ */
+export const OPD_VARIABLE = '$';
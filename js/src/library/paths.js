@@ -0,0 +1,136 @@
+import { applyObject } from './apply';
+import { JPLTypeError } from './errors/runtime';
+import { nativeFunction } from './functions';
+import { OPA_FIELD, OPA_SLICE, OPU_SET, OP_ACCESS, OP_ASSIGNMENT } from './ops';
+
+const identityFunction = nativeFunction((runtime, input) => [input]);
+
+const constantFunction = (value) => nativeFunction(() => [value ?? null]);
+
+/**
+ * Create selectors for the specified path, which behave like the corresponding literal path.
+ *
+ * Strings and numbers select fields, while objects of the form `{ from, to }` select slices.
+ */
+function pathSelectors(path) {
+  return path.map((key) => {
+    if (typeof key === 'object' && key !== null && !Array.isArray(key)) {
+      return {
+        op: OPA_SLICE,
+        params: { from: constantFunction(key.from), to: constantFunction(key.to), optional: false },
+      };
+    }
+    return { op: OPA_FIELD, params: { pipe: constantFunction(key), optional: false } };
+  });
+}
+
+/** Resolve the value at the specified path of unwrapped keys */
+export async function getPath(runtime, signal, value, path) {
+  const [result] = await runtime.op(
+    OP_ACCESS,
+    { pipe: identityFunction, selectors: pathSelectors(path) },
+    [value],
+    runtime.createScope({ signal }),
+  );
+  return result ?? null;
+}
+
+/** Replace the value at the specified path of unwrapped keys */
+export async function setPath(runtime, signal, value, path, replacement) {
+  const [result] = await runtime.op(
+    OP_ASSIGNMENT,
+    {
+      pipe: identityFunction,
+      selectors: pathSelectors(path),
+      assignment: { op: OPU_SET, params: { pipe: constantFunction(replacement) } },
+    },
+    [value],
+    runtime.createScope({ signal }),
+  );
+  return result ?? null;
+}
+
+/**
+ * Remove the values at all specified paths of unwrapped keys.
+ * Paths that do not exist are ignored.
+ *
+ * All paths refer to the original value, so removing array items does not affect the indices of the remaining paths.
+ */
+export async function deletePaths(runtime, signal, value, paths) {
+  // Negative indices are resolved against the original value first, so that they are sorted by their actual position
+  const resolved = paths.map((path) => resolvePath(runtime, value, path));
+
+  // Delete paths in descending order, so that removing array items does not shift the indices of the remaining paths
+  const sorted = resolved.sort((a, b) => runtime.compareArrays(b, a));
+
+  let result = value;
+  for (let i = 0; i < sorted.length; i += 1) {
+    if (i > 0 && runtime.equals(sorted[i], sorted[i - 1])) continue;
+    result = await deletePath(runtime, signal, result, sorted[i]);
+  }
+  return result;
+}
+
+/** Resolve all negative array indices of the specified path against the specified value */
+function resolvePath(runtime, value, path) {
+  let current = value;
+  return path.map((key) => {
+    const v = runtime.unwrapValue(current);
+    current = null;
+    switch (runtime.type(v)) {
+      case 'object':
+        if (runtime.type(key) === 'string' && Object.hasOwn(v, key)) current = v[key];
+        return key;
+
+      case 'array': {
+        if (runtime.type(key) !== 'number') return key;
+        const t = Math.trunc(key);
+        const i = t < 0 && v.length + t >= 0 ? v.length + t : t;
+        if (i >= 0 && i < v.length) current = v[i];
+        return i;
+      }
+
+      default:
+        return key;
+    }
+  });
+}
+
+async function deletePath(runtime, signal, value, path) {
+  if (path.length === 0) return null;
+
+  const parentPath = path.slice(0, -1);
+  const parent = await getPath(runtime, signal, value, parentPath);
+  const p = runtime.unwrapValue(parent);
+  const tp = runtime.type(p);
+  const key = path[path.length - 1];
+  const tk = runtime.type(key);
+
+  switch (tp) {
+    case 'null':
+      if (['string', 'number'].includes(tk)) return value;
+      break;
+
+    case 'object':
+      if (tk === 'string') {
+        if (!Object.hasOwn(p, key)) return value;
+        const altered = await runtime.alterValue(parent, (v) => applyObject(v, [[key]]));
+        return setPath(runtime, signal, value, parentPath, altered);
+      }
+      break;
+
+    case 'array':
+      if (tk === 'number') {
+        const t = Math.trunc(key);
+        const i = t >= 0 ? t : p.length + t;
+        if (i < 0 || i >= p.length) return value;
+        const altered = await runtime.alterValue(parent, (v) => v.toSpliced(i, 1));
+        return setPath(runtime, signal, value, parentPath, altered);
+      }
+      break;
+
+    default:
+  }
+
+  throw new JPLTypeError('cannot delete field of %s (%*<100v) with %s (%*<100v)', tp, p, tk, key);
+}
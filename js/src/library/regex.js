@@ -0,0 +1,145 @@
+import JPLRuntimeError from './errors/runtime';
+import { JPLType, unwrap } from './types';
+
+/**
+ * Flags that are supported by regular expressions.
+ *
+ * - `g`: Match all occurrences instead of only the first one
+ * - `i`: Match letters case insensitively
+ * - `m`: Let `^` and `$` match at the start and end of lines
+ * - `s`: Let `.` match newlines
+ */
+export const REGEX_FLAGS = 'gims';
+
+/** Maximum number of compiled regular expressions that are kept for reuse */
+const REGEX_CACHE_SIZE = 64;
+
+/** Cache of the most recently used compiled regular expressions, ordered from least to most recently used */
+const regexCache = new Map();
+
+/**
+ * Compile the specified regular expression.
+ * Only the most recently used expressions are cached, so that dynamic patterns cannot grow the cache indefinitely.
+ *
+ * The result has the form `{ pattern, flags, global, regexp, names }`.
+ */
+export function compileRegex(pattern, flags) {
+  const key = `${flags}/${pattern}`;
+  if (regexCache.has(key)) {
+    const cached = regexCache.get(key);
+    regexCache.delete(key);
+    regexCache.set(key, cached);
+    return cached;
+  }
+
+  const result = compileUncachedRegex(pattern, flags);
+  regexCache.set(key, result);
+  if (regexCache.size > REGEX_CACHE_SIZE) regexCache.delete(regexCache.keys().next().value);
+  return result;
+}
+
+function compileUncachedRegex(pattern, flags) {
+  const fail = (message) =>
+    new JPLRuntimeError(`invalid regular expression /${pattern}/${flags}: ${message}`);
+
+  let global = false;
+  let inline = '';
+  [...flags].forEach((flag, i) => {
+    if (!REGEX_FLAGS.includes(flag)) throw fail(`unknown flag ${flag}`);
+    if (flags.slice(0, i).includes(flag)) throw fail(`duplicate flag ${flag}`);
+    if (flag === 'g') global = true;
+    else inline += flag;
+  });
+
+  let regexp;
+  try {
+    // Unicode mode matches codepoints rather than UTF-16 code units
+    regexp = new RegExp(pattern, `${inline}dgu`);
+  } catch (err) {
+    const message = err.message.replace(/^.*: /, '');
+    throw fail(message.charAt(0).toLowerCase() + message.slice(1));
+  }
+
+  return { pattern, flags, global, regexp, names: captureNames(pattern) };
+}
+
+/** JPLType for regular expressions */
+export class JPLRegex extends JPLType {
+  constructor(pattern, flags) {
+    const regex = compileRegex(pattern, flags);
+    super({ pattern, flags });
+    this.regex = regex;
+  }
+}
+
+/**
+ * Resolve the regular expression for the specified normalized value.
+ * Besides regular expression literals, objects in the form `{ pattern, flags }` are accepted as well.
+ *
+ * If the value cannot be used as a regular expression, null is returned.
+ */
+export function resolveRegex(value) {
+  if (JPLRegex.is(value)) return value.regex;
+
+  const v = unwrap(value);
+  if (typeof v !== 'object' || v === null || Array.isArray(v)) return null;
+  if (typeof v.pattern !== 'string') return null;
+  if (v.flags != null && typeof v.flags !== 'string') return null;
+  return compileRegex(v.pattern, v.flags ?? '');
+}
+
+/**
+ * Find the matches of the regular expression in the specified string.
+ * Unless all is set, at most one match is returned.
+ *
+ * Each match is represented as `{ offset, length, string, captures: [{ offset, length, string, name }] }`, where offsets and lengths are specified in unicode codepoints.
+ * Captures that did not participate in the match have an offset of -1 and a string of null.
+ */
+export function findMatches(regex, value, all) {
+  const codepoints = (start, end) => [...value.slice(start, end)].length;
+  const match = (start, end) => ({
+    offset: codepoints(0, start),
+    length: codepoints(start, end),
+    string: value.slice(start, end),
+  });
+
+  const matches = [];
+  // Empty matches that directly follow the previous match are ignored
+  let previousEnd = -1;
+  for (const m of value.matchAll(regex.regexp)) {
+    const [start, end] = m.indices[0];
+    const ignore = start === end && start === previousEnd;
+    previousEnd = end;
+    if (!ignore) {
+      matches.push({
+        ...match(start, end),
+        captures: regex.names.map((name, i) => {
+          const indices = m.indices[i + 1];
+          if (!indices) return { offset: -1, length: 0, string: null, name };
+          return { ...match(...indices), name };
+        }),
+      });
+      if (!all) break;
+    }
+  }
+  return matches;
+}
+
+/** Resolve the names of all capturing groups in the specified pattern, which are null for unnamed groups */
+function captureNames(pattern) {
+  const names = [];
+  let escaped = false;
+  let inClass = false;
+  [...pattern].forEach((char, i) => {
+    if (escaped) escaped = false;
+    else if (char === '\\') escaped = true;
+    else if (inClass) inClass = char !== ']';
+    else if (char === '[') inClass = true;
+    else if (char === '(') {
+      const named = /^\(\?<([a-zA-Z_$][\w$]*)>/.exec(pattern.slice(i));
+      if (named) names.push(named[1]);
+      else if (pattern[i + 1] !== '?') names.push(null);
+    }
+  });
+  return names;
+}
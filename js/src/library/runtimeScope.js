@@ -12,6 +12,7 @@ class JPLRuntimeScope {
     this._state = {
       signal: presets.signal ?? new JPLRuntimeSignal(),
       vars: presets.vars ?? {},
+      labels: presets.labels ?? {},
     };
   }
 
@@ -23,11 +24,17 @@ class JPLRuntimeScope {
     return this._state.vars;
   }
 
+  /** Runtime areas of all labels that can be broken out of */
+  get labels() {
+    return this._state.labels;
+  }
+
   /** Inherit the next scope based on the specified modifications */
   next(modifications) {
     return new JPLRuntimeScope({
       signal: modifications.signal ?? this.signal,
       vars: applyObject(this.vars, Object.entries(modifications.vars ?? {})),
+      labels: applyObject(this.labels, Object.entries(modifications.labels ?? {})),
     });
   }
 }
@@ -1,6 +1,7 @@
 import { applyArray, applyObject } from './apply';
 import adaptErrors, { adaptError } from './errors/adaptErrors';
 import JPLFatalError from './errors/fatal';
+import JPLRuntimeError from './errors/runtime';
 
 /**
  * Generic type for handling special formatting on values.
@@ -128,32 +129,54 @@ export function stringify(value, unescapeString, escapeFunctions) {
 
 /**
  * Format the specified template string.
- * The general form of a format is a percent sign, followed by optional flags, an optional width and a verb.
+ * The general form of a format is a percent sign, followed by optional flags, an optional width, an optional precision and a verb.
  *
  * Examples:
  * - `%10s`: Format the next replacement as a string and pads the result at the left with spaces to be at least 10 unicode codepoints long.
  * - `%*<10v`: Format the next replacement as a JSON value and truncates it, if it is longer then 10 unicode codepoints.
+ * - `%08.2f`: Format the next replacement as a number with two decimals and pads the result at the left with zeros to be at least 8 unicode codepoints long.
  *
  * The width specifies the desired field width and defaults to whatever is necessary to display the full replacement.
  * If the width is specified without any other corresponding flags, it is used for padding the field if necessary.
  *
+ * The precision is introduced by a period and must not exceed 100.
+ * For the verbs `s` and `v`, it specifies the maximum number of unicode codepoints, which the replacement is cut off at.
+ * For the verbs `f` and `e`, it specifies the number of decimals and defaults to 6.
+ *
  * Valid flags:
  *
  * - `*`: Do not pad the value even if it is shorter than the specified width
  * - `-`: Pad the value at the right rather than the left
  * - `<`: Truncate the value at the right if it is too long for the specified width
+ * - `0`: Pad numbers with leading zeros after the sign rather than with spaces
+ * - `+`: Always print a sign for numbers
  *
  * Valid verbs:
  *
  * - `%`: Returns a literal `%`
  * - `s`: Format the next replacement as a string (like JSON, but does not escape strings)
  * - `v`: Format the next replacement as a JSON value
+ * - `d`: Format the next replacement, which must be a number, as an integer, rounding half away from zero
+ * - `f`: Format the next replacement, which must be a number, in fixed-point notation, rounding half up
+ * - `e`: Format the next replacement, which must be a number, in exponential notation, rounding half up
  */
 export function template(tmpl, ...replacements) {
+  return applyTemplate((message) => new JPLFatalError(message), tmpl, replacements);
+}
+
+/**
+ * Format the specified template string like `template`, but report invalid templates and replacements as runtime errors.
+ * This allows formatting templates that are provided by JPL programs.
+ */
+export function formatTemplate(tmpl, ...replacements) {
+  return applyTemplate((message) => new JPLRuntimeError(message), tmpl, replacements);
+}
+
+function applyTemplate(createError, tmpl, replacements) {
   let i = 0;
   return displayValue(tmpl).replace(
-    /%([*\-<]+)?([1-9][0-9]*)?(.)/g,
-    (match, flags, width, verb) => {
+    /%([*\-<0+]+)?([1-9][0-9]*)?(?:\.([0-9]+))?(.)/g,
+    (match, flags, width, precision, verb) => {
       // verbs without replacement
       switch (verb) {
         case '%':
@@ -163,7 +186,10 @@ export function template(tmpl, ...replacements) {
       // verbs with replacement
       const value = replacements[i] ?? null;
       i += 1;
+      const p = precision !== undefined ? +precision : -1;
+      if (p > 100) throw createError(`format ${match} has a precision above 100`);
       let result;
+      let numeric = false;
       switch (verb) {
         case 's':
           result = displayValue(value);
@@ -171,12 +197,19 @@ export function template(tmpl, ...replacements) {
         case 'v':
           result = strictDisplayValue(value);
           break;
+        case 'd':
+        case 'f':
+        case 'e':
+          [result, numeric] = formatNumber(createError, match, value, verb, p);
+          break;
         default:
-          throw new JPLFatalError(`format ${match} has unknown verb ${verb}`);
+          throw createError(`format ${match} has unknown verb ${verb}`);
       }
       let pad = true;
       let padRight = false;
       let trunc = false;
+      let zeros = false;
+      let sign = false;
       [...(flags ?? [])].forEach((flag) => {
         switch (flag) {
           case '*':
@@ -188,18 +221,36 @@ export function template(tmpl, ...replacements) {
           case '<':
             trunc = true;
             break;
+          case '0':
+            zeros = true;
+            break;
+          case '+':
+            sign = true;
+            break;
           default:
-            throw new JPLFatalError(`format ${match} has unknown flag ${flag}`);
+            throw createError(`format ${match} has unknown flag ${flag}`);
         }
       });
+      if (numeric) {
+        if (sign && !result.startsWith('-')) result = `+${result}`;
+      } else if (p >= 0 && [...result].length > p) {
+        result = [...result].slice(0, p).join('');
+      }
       const w = +(width ?? 0);
       if (w > 0) {
         const rl = [...result].length;
         if (pad && rl < w) {
-          const padding = ' '.repeat(w - rl);
-          result = padRight ? `${result}${padding}` : `${padding}${result}`;
+          if (numeric && zeros && !padRight) {
+            // Insert the zeros between the sign and the digits
+            const digits = result.replace(/^[+-]/, '');
+            const zeroPadding = '0'.repeat(w - rl);
+            result = `${result.substring(0, result.length - digits.length)}${zeroPadding}${digits}`;
+          } else {
+            const padding = ' '.repeat(w - rl);
+            result = padRight ? `${result}${padding}` : `${padding}${result}`;
+          }
         } else if (trunc && rl > w) {
-          result = `${result.substring(0, w - 1)}…`;
+          result = `${[...result].slice(0, w - 1).join('')}…`;
         }
       }
       return result;
@@ -207,6 +258,28 @@ export function template(tmpl, ...replacements) {
   );
 }
 
+/**
+ * Format the specified replacement for the numeric verbs `d`, `f` and `e`.
+ * Numbers that are not finite are formatted as JSON values and are not considered to be numeric.
+ */
+function formatNumber(createError, match, value, verb, precision) {
+  const u = unwrap(value);
+  if (typeof u !== 'number') {
+    throw createError(template('%s (%*<100v) cannot be formatted by %s', typeOf(u), u, match));
+  }
+  if (!Number.isFinite(u)) return [strictDisplayValue(u), false];
+  const p = precision >= 0 ? precision : 6;
+  switch (verb) {
+    case 'd':
+      // Adding zero normalizes negative zero
+      return [BigInt(Math.sign(u) * Math.round(Math.abs(u)) + 0).toString(), true];
+    case 'f':
+      return [u.toFixed(p), true];
+    default:
+      return [u.toExponential(p), true];
+  }
+}
+
 /** Format the specified normalized value as a string */
 export function displayValue(value) {
   return stringify(value, true, true);
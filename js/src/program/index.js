@@ -4,6 +4,7 @@ import {
   DEFINITION_VERSION_MAJOR,
   DEFINITION_VERSION_MINOR,
   JPLFatalError,
+  formatJSON,
 } from '../library';
 import JPLRuntime, { applyRuntimeDefaults } from '../runtime';
 import ops from './ops';
@@ -75,6 +76,17 @@ class JPLProgram {
     const outputs = await runtime.execute(normalizedInputs);
     return runtime.stripJSON(outputs);
   };
+
+  /**
+   * Run the program like `run` and stringify its outputs as JSON using the program's JSON options (`options.json`),
+   * which may be overridden by the provided program options.
+   */
+  runJSON = async (inputs, options) => {
+    const outputs = await this.run(inputs, options);
+
+    const { json } = applyProgramDefaults(options?.program, this._options);
+    return outputs.map((output) => formatJSON(output, json));
+  };
 }
 
 export default JPLProgram;
@@ -3,15 +3,20 @@ import {
   OP_AND,
   OP_ARRAY_CONSTRUCTOR,
   OP_ASSIGNMENT,
+  OP_BREAK,
   OP_CALCULATION,
   OP_COMPARISON,
   OP_CONSTANT,
   OP_CONSTANT_FALSE,
   OP_CONSTANT_NULL,
   OP_CONSTANT_TRUE,
+  OP_DELETE,
+  OP_DESTRUCTURING_DEFINITION,
   OP_FUNCTION_DEFINITION,
   OP_IF,
   OP_INTERPOLATED_STRING,
+  OP_LABEL,
+  OP_MATCH,
   OP_NEGATION,
   OP_NOT,
   OP_NULL_COALESCENCE,
@@ -19,6 +24,8 @@ import {
   OP_OBJECT_CONSTRUCTOR,
   OP_OR,
   OP_OUTPUT_CONCAT,
+  OP_REGEX,
+  OP_SPREAD,
   OP_STRING,
   OP_TRY,
   OP_VARIABLE,
@@ -29,15 +36,20 @@ import opAccess from './opAccess';
 import opAnd from './opAnd';
 import opArrayConstructor from './opArrayConstructor';
 import opAssignment from './opAssignment';
+import opBreak from './opBreak';
 import opCalculation from './opCalculation';
 import opComparison from './opComparison';
 import opConstant from './opConstant';
 import opConstantFalse from './opConstantFalse';
 import opConstantNull from './opConstantNull';
 import opConstantTrue from './opConstantTrue';
+import opDelete from './opDelete';
+import opDestructuringDefinition from './opDestructuringDefinition';
 import opFunctionDefinition from './opFunctionDefinition';
 import opIf from './opIf';
 import opInterpolatedString from './opInterpolatedString';
+import opLabel from './opLabel';
+import opMatch from './opMatch';
 import opNegation from './opNegation';
 import opNot from './opNot';
 import opNullCoalescence from './opNullCoalescence';
@@ -45,6 +57,8 @@ import opNumber from './opNumber';
 import opObjectConstructor from './opObjectConstructor';
 import opOr from './opOr';
 import opOutputConcat from './opOutputConcat';
+import opRegex from './opRegex';
+import opSpread from './opSpread';
 import opString from './opString';
 import opTry from './opTry';
 import opVariable from './opVariable';
@@ -56,15 +70,20 @@ const ops = {
   [OP_AND]: opAnd,
   [OP_ARRAY_CONSTRUCTOR]: opArrayConstructor,
   [OP_ASSIGNMENT]: opAssignment,
+  [OP_BREAK]: opBreak,
   [OP_CALCULATION]: opCalculation,
   [OP_COMPARISON]: opComparison,
   [OP_CONSTANT]: opConstant,
   [OP_CONSTANT_FALSE]: opConstantFalse,
   [OP_CONSTANT_NULL]: opConstantNull,
   [OP_CONSTANT_TRUE]: opConstantTrue,
+  [OP_DELETE]: opDelete,
+  [OP_DESTRUCTURING_DEFINITION]: opDestructuringDefinition,
   [OP_FUNCTION_DEFINITION]: opFunctionDefinition,
   [OP_IF]: opIf,
   [OP_INTERPOLATED_STRING]: opInterpolatedString,
+  [OP_LABEL]: opLabel,
+  [OP_MATCH]: opMatch,
   [OP_NEGATION]: opNegation,
   [OP_NOT]: opNot,
   [OP_NULL_COALESCENCE]: opNullCoalescence,
@@ -72,6 +91,8 @@ const ops = {
   [OP_OBJECT_CONSTRUCTOR]: opObjectConstructor,
   [OP_OR]: opOr,
   [OP_OUTPUT_CONCAT]: opOutputConcat,
+  [OP_REGEX]: opRegex,
+  [OP_SPREAD]: opSpread,
   [OP_STRING]: opString,
   [OP_TRY]: opTry,
   [OP_VARIABLE]: opVariable,
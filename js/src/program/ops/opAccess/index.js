@@ -1,8 +1,16 @@
-import { JPLFatalError, OPA_FIELD, OPA_FUNCTION, OPA_ITER, OPA_SLICE } from '../../../library';
+import {
+  JPLFatalError,
+  OPA_FIELD,
+  OPA_FUNCTION,
+  OPA_ITER,
+  OPA_RECURSIVE_DESCENT,
+  OPA_SLICE,
+} from '../../../library';
 import { call } from '../utils';
 import opaField from './opaField';
 import opaFunction from './opaFunction';
 import opaIter from './opaIter';
+import opaRecursiveDescent from './opaRecursiveDescent';
 import opaSlice from './opaSlice';
 
 export default {
@@ -51,5 +59,6 @@ const opas = {
   [OPA_FIELD]: opaField,
   [OPA_FUNCTION]: opaFunction,
   [OPA_ITER]: opaIter,
+  [OPA_RECURSIVE_DESCENT]: opaRecursiveDescent,
   [OPA_SLICE]: opaSlice,
 };
@@ -0,0 +1,100 @@
+import { JPLTypeError } from '../../../library';
+import { withKey } from '../opDelete/utils';
+import { call } from '../utils';
+
+export default {
+  /** { pipe: [op] | null, optional: boolean } */
+  async op(runtime, input, target, params, scope, next) {
+    const fields = await recursiveDescentFields(runtime, input, params, scope, '');
+
+    return walkRecursiveDescent(
+      runtime,
+      scope.signal,
+      { path: [], value: target },
+      fields,
+      !params.pipe,
+      (t) => next(t.value),
+    );
+  },
+
+  /** { pipe: function | null, optional: boolean } */
+  map(runtime, params) {
+    return {
+      pipe: params.pipe ? call(params.pipe) : undefined,
+      optional: runtime.assertType(params.optional, 'boolean'),
+    };
+  },
+};
+
+/**
+ * Resolve the fields that are matched by a recursive descent.
+ * Strings match object fields, whereas numbers match array items.
+ */
+export async function recursiveDescentFields(runtime, input, params, scope, suffix) {
+  if (!params.pipe) return [];
+
+  return runtime.executeInstructions(params.pipe, [input], scope, (output) => {
+    const field = runtime.unwrapValue(output);
+    const tf = runtime.type(field);
+    switch (tf) {
+      case 'string':
+      case 'number':
+        return [field];
+
+      default:
+    }
+
+    if (params.optional) return [];
+    throw new JPLTypeError(
+      `cannot descend recursively into fields with %s (%*<100v)${suffix}`,
+      tf,
+      field,
+    );
+  });
+}
+
+/**
+ * Visit all values below the specified target in pre-order, which either match one of the specified fields or, if all is set, any value.
+ * Array items are visited by index, object fields are visited in sorted key order.
+ */
+export async function walkRecursiveDescent(runtime, signal, target, fields, all, cb) {
+  // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+  await undefined;
+
+  signal.checkHealth();
+
+  const value = runtime.unwrapValue(target.value);
+  let matches = [];
+  let children = [];
+  switch (runtime.type(value)) {
+    case 'object':
+      matches = fields
+        .filter((field) => typeof field === 'string' && Object.hasOwn(value, field))
+        .map((field) => withKey(target, field, value[field]));
+      children = Object.keys(value)
+        .sort()
+        .map((key) => withKey(target, key, value[key]));
+      break;
+
+    case 'array':
+      matches = fields
+        .filter((field) => typeof field === 'number')
+        .map((field) => {
+          const t = Math.trunc(field);
+          return t >= 0 ? t : value.length + t;
+        })
+        .filter((i) => i >= 0 && i < value.length)
+        .map((i) => withKey(target, i, value[i]));
+      children = value.map((item, i) => withKey(target, i, item));
+      break;
+
+    default:
+  }
+
+  const results = [...matches.map((t) => cb(t))];
+  children.forEach((child) => {
+    if (all) results.push(cb(child));
+    results.push(walkRecursiveDescent(runtime, signal, child, fields, all, cb));
+  });
+  return (await Promise.all(results)).flat(1);
+}
@@ -2,6 +2,7 @@ import {
   JPLFatalError,
   OPA_FIELD,
   OPA_ITER,
+  OPA_RECURSIVE_DESCENT,
   OPA_SLICE,
   OPU_ADDITION,
   OPU_DIVISION,
@@ -15,6 +16,7 @@ import {
 import { call } from '../utils';
 import opaAssignField from './opaAssignField';
 import opaAssignIter from './opaAssignIter';
+import opaAssignRecursiveDescent from './opaAssignRecursiveDescent';
 import opaAssignSlice from './opaAssignSlice';
 import opuAddition from './opuAddition';
 import opuDivision from './opuDivision';
@@ -87,6 +89,7 @@ export default {
 const opasAssign = {
   [OPA_FIELD]: opaAssignField,
   [OPA_ITER]: opaAssignIter,
+  [OPA_RECURSIVE_DESCENT]: opaAssignRecursiveDescent,
   [OPA_SLICE]: opaAssignSlice,
 };
 
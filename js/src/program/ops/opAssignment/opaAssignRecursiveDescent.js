@@ -0,0 +1,84 @@
+import { applyArray, applyObject } from '../../../library';
+import opaRecursiveDescent, { recursiveDescentFields } from '../opAccess/opaRecursiveDescent';
+import opaAssignIter from './opaAssignIter';
+
+export default {
+  /**
+   * { pipe: [op] | null, optional: boolean }
+   *
+   * Nested values are assigned before the values that contain them.
+   */
+  async op(runtime, input, target, params, scope, next) {
+    const fields = await recursiveDescentFields(runtime, input, params, scope, ' (assignment)');
+    const all = !params.pipe;
+
+    const assignFields = async (from, source) => {
+      if (from >= fields.length) return [source];
+
+      let item;
+      let apply;
+      const s = runtime.unwrapValue(source);
+      const field = fields[from];
+      switch (runtime.type(s)) {
+        case 'object':
+          if (typeof field === 'string' && Object.hasOwn(s, field)) {
+            item = s[field];
+            apply = (value, output) => applyObject(value, [[field, output]]);
+          }
+          break;
+
+        case 'array':
+          if (typeof field === 'number') {
+            const t = Math.trunc(field);
+            const i = t >= 0 ? t : s.length + t;
+            if (i >= 0 && i < s.length) {
+              item = s[i];
+              apply = (value, output) => applyArray(value, [[i, output]]);
+            }
+          }
+          break;
+
+        default:
+      }
+
+      if (!apply) return assignFields(from + 1, source);
+
+      return runtime.muxAll([await next(item ?? null)], async (output) => {
+        if (output === undefined) return assignFields(from + 1, source);
+        return assignFields(
+          from + 1,
+          await runtime.alterValue(source, (value) => apply(value, output)),
+        );
+      });
+    };
+
+    const visit = async (value) => {
+      // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+      await undefined;
+
+      scope.signal.checkHealth();
+
+      if (!['object', 'array'].includes(runtime.type(value))) return [value];
+
+      const results = await opaAssignIter.op(runtime, input, value, {}, scope, async (item) => {
+        const values = await visit(item);
+        if (!all) return values;
+        return runtime.muxAll([values], async (visited) =>
+          runtime.muxOne([await next(visited)], (output) => {
+            if (output === undefined) return visited;
+            return output;
+          }),
+        );
+      });
+      if (all) return results;
+      return runtime.muxAll([results], (result) => assignFields(0, result));
+    };
+
+    return visit(target);
+  },
+
+  /** { pipe: function | null, optional: boolean } */
+  map(runtime, params) {
+    return opaRecursiveDescent.map(runtime, params);
+  },
+};
@@ -0,0 +1,31 @@
+import { JPLReferenceError, JPLRuntimeError } from '../../library';
+
+export default {
+  /** { name: string } */
+  op(runtime, input, params, scope) {
+    if (!Object.hasOwn(scope.labels, params.name ?? ''))
+      throw new JPLReferenceError('label %s is not defined', params.name ?? '');
+    const signal = scope.labels[params.name ?? ''];
+    // Breaking out of a label is only possible from within its own runtime area
+    if (!isWithin(scope.signal, signal))
+      throw new JPLRuntimeError('label %s has already been exited', params.name ?? '');
+    // Exiting the label's runtime area aborts its execution, which is then handled by the label itself
+    signal.exit();
+    signal.checkHealth();
+    return [];
+  },
+
+  /** { name: string } */
+  map(runtime, params) {
+    return {
+      name: runtime.assertType(params.name, 'string'),
+    };
+  },
+};
+
+function isWithin(signal, area) {
+  for (let current = signal; current; current = current.parent) {
+    if (current === area) return true;
+  }
+  return false;
+}
@@ -0,0 +1,74 @@
+import {
+  JPLFatalError,
+  OPA_FIELD,
+  OPA_ITER,
+  OPA_RECURSIVE_DESCENT,
+  OPA_SLICE,
+  deletePaths,
+} from '../../../library';
+import opaDeleteField from './opaDeleteField';
+import opaDeleteIter from './opaDeleteIter';
+import opaDeleteRecursiveDescent from './opaDeleteRecursiveDescent';
+import opaDeleteSlice from './opaDeleteSlice';
+
+export default {
+  /** { paths: [[opa]] } */
+  async op(runtime, input, params, scope, next) {
+    const iter = async (selectors, from, target) => {
+      // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+      await undefined;
+
+      scope.signal.checkHealth();
+
+      if (from >= selectors.length) return [target.path];
+
+      const { op, params: opParams } = selectors[from];
+      const operator = opasDelete[op];
+      if (!operator) throw new JPLFatalError(`invalid OPA '${op}' (deletion)`);
+
+      return operator.op(runtime, input, target, opParams ?? {}, scope, (output) =>
+        iter(selectors, from + 1, output),
+      );
+    };
+
+    const results = await runtime.muxAll([params.paths ?? []], (selectors) =>
+      iter(selectors ?? [], 0, { path: [], value: input }),
+    );
+
+    const paths = results.flatMap((path) => {
+      // Trailing slices are expanded into their items, so that they are removed alongside all other paths
+      const last = path[path.length - 1];
+      if (typeof last !== 'object') return [path];
+      return Array.from({ length: last.to - last.from }, (_, i) => [
+        ...path.slice(0, -1),
+        last.from + i,
+      ]);
+    });
+
+    return next(await deletePaths(runtime, scope.signal, input, paths), scope);
+  },
+
+  /** { paths: [[opa]] } */
+  map(runtime, params) {
+    return {
+      paths: runtime.muxOne([params.paths], (selectors) =>
+        runtime.muxOne([selectors], ({ op, params: opParams }) => {
+          const operator = opasDelete[op];
+          if (!operator) throw new JPLFatalError(`invalid OPA '${op}' (deletion)`);
+
+          return {
+            op: runtime.assertType(op, 'string'),
+            params: operator.map(runtime, opParams),
+          };
+        }),
+      ),
+    };
+  },
+};
+
+const opasDelete = {
+  [OPA_FIELD]: opaDeleteField,
+  [OPA_ITER]: opaDeleteIter,
+  [OPA_RECURSIVE_DESCENT]: opaDeleteRecursiveDescent,
+  [OPA_SLICE]: opaDeleteSlice,
+};
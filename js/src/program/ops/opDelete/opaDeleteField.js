@@ -0,0 +1,60 @@
+import { JPLTypeError } from '../../../library';
+import opaField from '../opAccess/opaField';
+import { withKey } from './utils';
+
+export default {
+  /** { pipe: [op], optional: boolean } */
+  op(runtime, input, target, params, scope, next) {
+    const value = runtime.unwrapValue(target.value);
+    const tv = runtime.type(value);
+
+    return runtime.executeInstructions(params.pipe ?? [], [input], scope, (output) => {
+      const field = runtime.unwrapValue(output);
+      const tf = runtime.type(field);
+      switch (tv) {
+        case 'null':
+          if (['string', 'number'].includes(tf)) return next(withKey(target, field, null));
+          break;
+
+        case 'object':
+          if (tf === 'string') return next(withKey(target, field, value[field]));
+          break;
+
+        case 'array':
+          if (tf === 'number') {
+            const t = Math.trunc(field);
+            const i = t >= 0 ? t : value.length + t;
+            if (i < 0) return [];
+            return next(withKey(target, i, value[i]));
+          }
+          break;
+
+        case 'string':
+          if (tf === 'number') {
+            const chars = [...value];
+            const t = Math.trunc(field);
+            const i = t >= 0 ? t : chars.length + t;
+            if (i < 0) return [];
+            return next(withKey(target, i, chars[i]));
+          }
+          break;
+
+        default:
+      }
+
+      if (params.optional) return [];
+      throw new JPLTypeError(
+        'cannot access field of %s (%*<100v) with %s (%*<100v) (deletion)',
+        tv,
+        value,
+        tf,
+        field,
+      );
+    });
+  },
+
+  /** { pipe: function, optional: boolean } */
+  map(runtime, params) {
+    return opaField.map(runtime, params);
+  },
+};
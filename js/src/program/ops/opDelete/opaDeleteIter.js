@@ -0,0 +1,38 @@
+import { JPLTypeError } from '../../../library';
+import opaIter from '../opAccess/opaIter';
+import { withKey } from './utils';
+
+export default {
+  /** { optional: boolean } */
+  op(runtime, input, target, params, scope, next) {
+    const value = runtime.unwrapValue(target.value);
+    const t = runtime.type(value);
+    switch (t) {
+      case 'null':
+        return [];
+
+      case 'object':
+        return runtime.muxAll([Object.entries(value)], ([k, v]) => next(withKey(target, k, v)));
+
+      case 'array':
+        return runtime.muxAll([value.map((v, i) => [i, v])], ([i, v]) =>
+          next(withKey(target, i, v)),
+        );
+
+      case 'string':
+        return runtime.muxAll([[...value].map((v, i) => [i, v])], ([i, v]) =>
+          next(withKey(target, i, v)),
+        );
+
+      default:
+    }
+
+    if (params.optional) return [];
+    throw new JPLTypeError('cannot iterate over %s (%*<100v) (deletion)', t, value);
+  },
+
+  /** { optional: boolean } */
+  map(runtime, params) {
+    return opaIter.map(runtime, params);
+  },
+};
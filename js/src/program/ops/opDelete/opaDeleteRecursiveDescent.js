@@ -0,0 +1,18 @@
+import opaRecursiveDescent, {
+  recursiveDescentFields,
+  walkRecursiveDescent,
+} from '../opAccess/opaRecursiveDescent';
+
+export default {
+  /** { pipe: [op] | null, optional: boolean } */
+  async op(runtime, input, target, params, scope, next) {
+    const fields = await recursiveDescentFields(runtime, input, params, scope, ' (deletion)');
+
+    return walkRecursiveDescent(runtime, scope.signal, target, fields, !params.pipe, next);
+  },
+
+  /** { pipe: function | null, optional: boolean } */
+  map(runtime, params) {
+    return opaRecursiveDescent.map(runtime, params);
+  },
+};
@@ -0,0 +1,56 @@
+import { JPLTypeError } from '../../../library';
+import opaSlice from '../opAccess/opaSlice';
+import { sliceBounds, withKey } from './utils';
+
+export default {
+  /** { from: [op], to: [op], optional: boolean } */
+  async op(runtime, input, target, params, scope, next) {
+    const value = runtime.unwrapValue(target.value);
+    const tv = runtime.type(value);
+
+    const [froms, tos] = await Promise.all([
+      runtime.executeInstructions(params.from ?? [], [input], scope),
+      runtime.executeInstructions(params.to ?? [], [input], scope),
+    ]);
+
+    return runtime.muxAll([runtime.unwrapValues(froms), runtime.unwrapValues(tos)], (from, to) => {
+      const tf = runtime.type(from);
+      const tt = runtime.type(to);
+      if (['number', 'null'].includes(tf) && ['number', 'null'].includes(tt)) {
+        switch (tv) {
+          case 'null':
+            return [];
+
+          case 'array': {
+            const [s, e] = sliceBounds(value.length, from, to);
+            return next(withKey(target, { from: s, to: e }, value.slice(s, e)));
+          }
+
+          case 'string': {
+            const chars = [...value];
+            const [s, e] = sliceBounds(chars.length, from, to);
+            return next(withKey(target, { from: s, to: e }, chars.slice(s, e).join('')));
+          }
+
+          default:
+        }
+      }
+
+      if (params.optional) return [];
+      throw new JPLTypeError(
+        'cannot slice %s (%*<100v) with %s (%*<100v) and %s (%*<100v) (deletion)',
+        tv,
+        value,
+        tf,
+        from,
+        tt,
+        to,
+      );
+    });
+  },
+
+  /** { from: function, to: function, optional: boolean } */
+  map(runtime, params) {
+    return opaSlice.map(runtime, params);
+  },
+};
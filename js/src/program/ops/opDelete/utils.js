@@ -0,0 +1,13 @@
+/** Create the target for the specified key of the concrete path to a value */
+export function withKey(target, key, value) {
+  return { path: [...target.path, key], value: value ?? null };
+}
+
+/** Resolve the absolute bounds of the slice `[from:to]` for the specified length */
+export function sliceBounds(length, from, to) {
+  const f = Math.trunc(from ?? 0);
+  const t = Math.trunc(to ?? length);
+  const s = f >= 0 ? Math.min(length, f) : Math.max(0, length + f);
+  const e = t >= 0 ? Math.min(length, t) : Math.max(0, length + t);
+  return [s, Math.max(s, e)];
+}
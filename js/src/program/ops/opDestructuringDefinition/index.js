@@ -0,0 +1,34 @@
+import { JPLFatalError } from '../../../library';
+import { call } from '../utils';
+import { bindPattern, mapPattern } from './utils';
+
+export default {
+  /** { pattern: opd, pipe: [op] } */
+  op(runtime, input, params, scope, next) {
+    if (!params.pattern) throw new JPLFatalError('missing pattern');
+
+    return runtime.executeInstructions(params.pipe ?? [], [input], scope, (output) =>
+      bindPattern(
+        runtime,
+        input,
+        output,
+        params.pattern,
+        scope,
+        (_, patternScope) => next(input, patternScope),
+        (cause) => {
+          throw cause();
+        },
+      ),
+    );
+  },
+
+  /** { pattern: opd, pipe: function } */
+  map(runtime, params) {
+    if (!params.pattern) throw new JPLFatalError('missing pattern');
+
+    return {
+      pattern: mapPattern(runtime, params.pattern),
+      pipe: call(params.pipe),
+    };
+  },
+};
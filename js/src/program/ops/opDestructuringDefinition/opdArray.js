@@ -0,0 +1,58 @@
+import { JPLTypeError } from '../../../library';
+import { bindMissingEntry, bindPattern, mapPatternEntries } from './utils';
+
+export default {
+  /** { entries: [{ pattern: opd, default: [op], optional: boolean }], rest: string } */
+  op(runtime, input, target, params, scope, next, mismatch) {
+    const value = runtime.unwrapValue(target);
+    const t = runtime.type(value);
+    if (t !== 'array') {
+      return mismatch(
+        () => new JPLTypeError('cannot destructure %s (%*<100v) as array', t, value),
+      );
+    }
+
+    const entries = params.entries ?? [];
+
+    const iter = async (from, iterScope) => {
+      // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+      await undefined;
+
+      iterScope.signal.checkHealth();
+
+      if (from >= entries.length) {
+        if (!params.rest) return next(target, iterScope);
+
+        return next(target, iterScope.next({ vars: { [params.rest]: value.slice(from) } }));
+      }
+
+      const entry = entries[from];
+      const piper = (_, entryScope) => iter(from + 1, entryScope);
+
+      if (from < value.length) {
+        return bindPattern(runtime, input, value[from], entry.pattern, iterScope, piper, mismatch);
+      }
+
+      return bindMissingEntry(
+        runtime,
+        input,
+        entry,
+        iterScope,
+        piper,
+        mismatch,
+        () =>
+          new JPLTypeError('cannot destructure missing item %d of array (%*<100v)', from, value),
+      );
+    };
+
+    return iter(0, scope);
+  },
+
+  /** { entries: [{ pattern: opd, default: function, optional: boolean }], rest: string } */
+  map(runtime, params) {
+    return {
+      entries: mapPatternEntries(runtime, params.entries, false),
+      rest: params.rest != null ? runtime.assertType(params.rest, 'string') : undefined,
+    };
+  },
+};
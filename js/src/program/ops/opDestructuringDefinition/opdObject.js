@@ -0,0 +1,88 @@
+import { JPLTypeError } from '../../../library';
+import { bindMissingEntry, bindPattern, mapPatternEntries } from './utils';
+
+export default {
+  /** { entries: [{ key: [op], pattern: opd, default: [op], optional: boolean }], rest: string } */
+  op(runtime, input, target, params, scope, next, mismatch) {
+    const value = runtime.unwrapValue(target);
+    const t = runtime.type(value);
+    if (t !== 'object') {
+      return mismatch(
+        () => new JPLTypeError('cannot destructure %s (%*<100v) as object', t, value),
+      );
+    }
+
+    const entries = params.entries ?? [];
+
+    const iter = async (from, keys, iterScope) => {
+      // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+      await undefined;
+
+      iterScope.signal.checkHealth();
+
+      if (from >= entries.length) {
+        if (!params.rest) return next(target, iterScope);
+
+        const rest = Object.fromEntries(Object.entries(value).filter(([k]) => !keys.includes(k)));
+        return next(target, iterScope.next({ vars: { [params.rest]: rest } }));
+      }
+
+      const entry = entries[from];
+      const piper = (nextKeys) => (_, entryScope) => iter(from + 1, nextKeys, entryScope);
+
+      return runtime.executeInstructions(entry.key ?? [], [input], iterScope, (output) => {
+        const key = runtime.unwrapValue(output);
+        const tk = runtime.type(key);
+        if (tk !== 'string') {
+          return bindMissingEntry(
+            runtime,
+            input,
+            entry,
+            iterScope,
+            piper(keys),
+            mismatch,
+            () => new JPLTypeError('cannot use %s (%*<100v) as object key', tk, key),
+          );
+        }
+
+        const nextKeys = [...keys, key];
+        if (Object.hasOwn(value, key)) {
+          return bindPattern(
+            runtime,
+            input,
+            value[key],
+            entry.pattern,
+            iterScope,
+            piper(nextKeys),
+            mismatch,
+          );
+        }
+
+        return bindMissingEntry(
+          runtime,
+          input,
+          entry,
+          iterScope,
+          piper(nextKeys),
+          mismatch,
+          () =>
+            new JPLTypeError(
+              'cannot destructure missing field %*<100v of object (%*<100v)',
+              key,
+              value,
+            ),
+        );
+      });
+    };
+
+    return iter(0, [], scope);
+  },
+
+  /** { entries: [{ key: function, pattern: opd, default: function, optional: boolean }], rest: string } */
+  map(runtime, params) {
+    return {
+      entries: mapPatternEntries(runtime, params.entries, true),
+      rest: params.rest != null ? runtime.assertType(params.rest, 'string') : undefined,
+    };
+  },
+};
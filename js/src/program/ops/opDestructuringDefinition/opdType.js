@@ -0,0 +1,26 @@
+import { JPLTypeError } from '../../../library';
+import { bindPattern, mapPattern } from './utils';
+
+export default {
+  /** { type: string, pattern: opd } */
+  op(runtime, input, target, params, scope, next, mismatch) {
+    const value = runtime.unwrapValue(target);
+    const t = runtime.type(value);
+    if (t !== params.type) {
+      return mismatch(
+        () => new JPLTypeError('cannot destructure %s (%*<100v) as %s', t, value, params.type),
+      );
+    }
+
+    if (!params.pattern) return next(target, scope);
+    return bindPattern(runtime, input, target, params.pattern, scope, next, mismatch);
+  },
+
+  /** { type: string, pattern: opd } */
+  map(runtime, params) {
+    return {
+      type: runtime.assertType(params.type, 'string'),
+      pattern: params.pattern ? mapPattern(runtime, params.pattern) : undefined,
+    };
+  },
+};
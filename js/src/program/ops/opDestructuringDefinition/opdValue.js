@@ -0,0 +1,26 @@
+import { JPLTypeError } from '../../../library';
+import { call } from '../utils';
+
+export default {
+  /** { pipe: [op] } */
+  async op(runtime, input, target, params, scope, next, mismatch) {
+    const values = await runtime.executeInstructions(params.pipe ?? [], [input], scope);
+    if (values.some((value) => runtime.equals(target, value))) return next(target, scope);
+
+    return mismatch(() => {
+      const value = runtime.unwrapValue(target);
+      return new JPLTypeError(
+        'cannot destructure %s (%*<100v) as expected value',
+        runtime.type(value),
+        value,
+      );
+    });
+  },
+
+  /** { pipe: function } */
+  map(runtime, params) {
+    return {
+      pipe: call(params.pipe),
+    };
+  },
+};
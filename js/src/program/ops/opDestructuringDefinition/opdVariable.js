@@ -0,0 +1,13 @@
+export default {
+  /** { name: string } */
+  op(runtime, input, target, params, scope, next, mismatch) {
+    return next(target, scope.next({ vars: { [params.name ?? '']: target } }));
+  },
+
+  /** { name: string } */
+  map(runtime, params) {
+    return {
+      name: runtime.assertType(params.name, 'string'),
+    };
+  },
+};
@@ -0,0 +1,89 @@
+import {
+  JPLFatalError,
+  OPD_ARRAY,
+  OPD_OBJECT,
+  OPD_TYPE,
+  OPD_VALUE,
+  OPD_VARIABLE,
+} from '../../../library';
+import { call } from '../utils';
+import opdArray from './opdArray';
+import opdObject from './opdObject';
+import opdType from './opdType';
+import opdValue from './opdValue';
+import opdVariable from './opdVariable';
+
+const opds = {
+  [OPD_ARRAY]: opdArray,
+  [OPD_OBJECT]: opdObject,
+  [OPD_TYPE]: opdType,
+  [OPD_VALUE]: opdValue,
+  [OPD_VARIABLE]: opdVariable,
+};
+
+/**
+ * Bind the specified target to the pattern and pass the resulting scope to next.
+ *
+ * Targets that do not match the pattern are passed to mismatch, which decides whether the mismatch results in the specified error or whether the target is skipped.
+ */
+export async function bindPattern(runtime, input, target, pattern, scope, next, mismatch) {
+  // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+  await undefined;
+
+  scope.signal.checkHealth();
+
+  const { op, params } = pattern;
+  const operator = opds[op];
+  if (!operator) throw new JPLFatalError(`invalid OPD '${op}'`);
+
+  return operator.op(runtime, input, target, params ?? {}, scope, next, mismatch);
+}
+
+/**
+ * Bind the default value of the specified entry, whose target is missing.
+ * Optional entries without a default value bind null to all of their variables.
+ */
+export function bindMissingEntry(runtime, input, entry, scope, next, mismatch, cause) {
+  if (entry.default) {
+    return runtime.executeInstructions(entry.default, [input], scope, (output) =>
+      bindPattern(runtime, input, output, entry.pattern, scope, next, mismatch),
+    );
+  }
+
+  if (!entry.optional) return mismatch(cause);
+
+  const vars = Object.fromEntries(patternVariables(entry.pattern).map((name) => [name, null]));
+  return next(null, scope.next({ vars }));
+}
+
+/** Resolve the names of all variables that are bound by the specified pattern */
+export function patternVariables(pattern) {
+  const { name, pattern: subPattern, entries, rest } = pattern.params ?? {};
+  return [
+    ...(name ? [name] : []),
+    ...(subPattern ? patternVariables(subPattern) : []),
+    ...(entries ?? []).flatMap((entry) => patternVariables(entry.pattern)),
+    ...(rest ? [rest] : []),
+  ];
+}
+
+/** Map the specified pattern and its sub patterns */
+export function mapPattern(runtime, { op, params }) {
+  const operator = opds[op];
+  if (!operator) throw new JPLFatalError(`invalid OPD '${op}'`);
+
+  return {
+    op: runtime.assertType(op, 'string'),
+    params: operator.map(runtime, params),
+  };
+}
+
+/** Map the specified pattern entries, optionally including their keys */
+export function mapPatternEntries(runtime, entries, keys) {
+  return runtime.muxOne([entries], (entry) => ({
+    key: keys ? call(entry.key) : undefined,
+    pattern: mapPattern(runtime, entry.pattern),
+    default: entry.default ? call(entry.default) : undefined,
+    optional: runtime.assertType(entry.optional, 'boolean'),
+  }));
+}
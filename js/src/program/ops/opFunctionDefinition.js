@@ -1,16 +1,34 @@
-import { scopedFunction } from '../../library';
+import { scopedFunction, typedFunction } from '../../library';
 import { call } from './utils';
 
 export default {
-  /** { argNames: [string], pipe: [op] } */
+  /**
+   * { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: [op] }], pipe: [op] }
+   */
   op(runtime, input, params, scope, next) {
+    if (params.args) {
+      return next(typedFunction(params.name, params.args, params.pipe ?? [], scope), scope);
+    }
     return next(scopedFunction(params.argNames ?? [], params.pipe ?? [], scope), scope);
   },
 
-  /** { argNames: [string], pipe: function } */
+  /**
+   * { name: string, argNames: [string], args: [{ name: string, types: [string], optional: boolean, default: function }], pipe: function }
+   */
   map(runtime, params) {
     return {
+      name: runtime.assertType(params.name ?? '', 'string'),
       argNames: runtime.muxOne([params.argNames], (entry) => runtime.assertType(entry, 'string')),
+      args: params.args
+        ? runtime.muxOne([params.args], (arg) => ({
+            name: runtime.assertType(arg.name, 'string'),
+            types: runtime.muxOne([arg.types ?? []], (entry) =>
+              runtime.assertType(entry, 'string'),
+            ),
+            optional: runtime.assertType(arg.optional, 'boolean'),
+            default: arg.default ? call(arg.default) : undefined,
+          }))
+        : undefined,
       pipe: call(params.pipe),
     };
   },
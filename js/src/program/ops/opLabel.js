@@ -0,0 +1,47 @@
+import { JPLErrorEnclosure } from '../../library';
+import { call } from './utils';
+
+export default {
+  /** { name: string, pipe: [op] } */
+  async op(runtime, input, params, scope, next) {
+    const signal = scope.signal.next();
+    const nextScope = scope.next({ signal, labels: { [params.name ?? '']: signal } });
+
+    // Results are collected as they are produced, so that they are kept when breaking out of the label.
+    // The labeled expression is executed sequentially, so that all results are in program order.
+    const results = [];
+    try {
+      try {
+        await runtime
+          .sequential()
+          .executeInstructions(params.pipe ?? [], [input], nextScope, async (output) => {
+            let outputs;
+            try {
+              outputs = await next(output, scope);
+            } catch (err) {
+              throw new JPLErrorEnclosure(err);
+            }
+            results.push(...outputs);
+            return [];
+          });
+      } catch (err) {
+        // The label has been broken out of if its own runtime area has been exited, but not the surrounding one
+        if (JPLErrorEnclosure.is(err) || !signal.exited || scope.signal.exited) throw err;
+      }
+      return results;
+    } catch (err) {
+      if (JPLErrorEnclosure.is(err)) throw err.inner;
+      throw err;
+    } finally {
+      signal.exit();
+    }
+  },
+
+  /** { name: string, pipe: function } */
+  map(runtime, params) {
+    return {
+      name: runtime.assertType(params.name, 'string'),
+      pipe: call(params.pipe),
+    };
+  },
+};
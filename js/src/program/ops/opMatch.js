@@ -0,0 +1,74 @@
+import { JPLRuntimeError } from '../../library';
+import { bindPattern, mapPattern } from './opDestructuringDefinition/utils';
+import { call } from './utils';
+
+export default {
+  /** { pipe: [op], cases: [{ pattern: opd, if: [op], then: [op] }], else: [op] } */
+  op(runtime, input, params, scope, next) {
+    return runtime.executeInstructions(params.pipe ?? [], [input], scope, (target) => {
+      const iter = async (from) => {
+        // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
+        await undefined;
+
+        scope.signal.checkHealth();
+
+        if (from >= (params.cases?.length ?? 0)) {
+          if (!params.else) {
+            const value = runtime.unwrapValue(target);
+            throw new JPLRuntimeError(
+              'no pattern matches %s (%*<100v)',
+              runtime.type(value),
+              value,
+            );
+          }
+
+          return runtime.executeInstructions(params.else, [input], scope, (output) =>
+            next(output, scope),
+          );
+        }
+
+        const matchCase = params.cases[from];
+
+        // Collect the scopes of all bindings that satisfy the guard before executing any branch
+        const scopes = await bindPattern(
+          runtime,
+          input,
+          target,
+          matchCase.pattern,
+          scope,
+          (_, caseScope) => {
+            if (!matchCase.if) return [caseScope];
+
+            return runtime.executeInstructions(matchCase.if, [input], caseScope, (result) =>
+              runtime.truthy(result) ? [caseScope] : [],
+            );
+          },
+          () => [],
+        );
+
+        if (scopes.length === 0) return iter(from + 1);
+
+        return runtime.muxAll([scopes], (caseScope) =>
+          runtime.executeInstructions(matchCase.then ?? [], [input], caseScope, (output) =>
+            next(output, scope),
+          ),
+        );
+      };
+
+      return iter(0);
+    });
+  },
+
+  /** { pipe: function, cases: [{ pattern: opd, if: function, then: function }], else: function } */
+  map(runtime, params) {
+    return {
+      pipe: call(params.pipe),
+      cases: runtime.muxOne([params.cases], (entry) => ({
+        pattern: mapPattern(runtime, entry.pattern),
+        if: entry.if ? call(entry.if) : undefined,
+        then: call(entry.then),
+      })),
+      else: params.else ? call(params.else) : undefined,
+    };
+  },
+};
@@ -2,9 +2,11 @@ import { JPLTypeError } from '../../library';
 import { call } from './utils';
 
 export default {
-  /** { fields: [{ key: [op], value: [op], optional: boolean }] } */
+  /** { fields: [{ key: [op], value: [op], optional: boolean, spread: boolean }] } */
   async op(runtime, input, params, scope, next) {
     const fields = await runtime.muxAsync([params.fields ?? []], async (field) => {
+      if (field.spread) return spreadObjects(runtime, input, field, scope);
+
       const [keys, values] = await Promise.all([
         runtime.executeInstructions(field.key ?? [], [input], scope),
         runtime.executeInstructions(field.value ?? [], [input], scope),
@@ -14,7 +16,7 @@ export default {
         const t = runtime.type(key);
         switch (t) {
           case 'string':
-            return [[key, value]];
+            return [[[key, value]]];
 
           default:
         }
@@ -24,17 +26,31 @@ export default {
       });
     });
 
-    return runtime.muxAll(fields, (...entries) => next(Object.fromEntries(entries), scope));
+    return runtime.muxAll(fields, (...entries) => next(Object.fromEntries(entries.flat(1)), scope));
   },
 
-  /** { fields: [{ key: function, value: function, optional: boolean }] } */
+  /** { fields: [{ key: function, value: function, optional: boolean, spread: boolean }] } */
   map(runtime, params) {
     return {
       fields: runtime.muxOne([params.fields], (entry) => ({
-        key: call(entry.key),
+        key: entry.spread ? undefined : call(entry.key),
         value: call(entry.value),
         optional: runtime.assertType(entry.optional, 'boolean'),
+        spread: runtime.assertType(entry.spread ?? false, 'boolean'),
       })),
     };
   },
 };
+
+/** Resolve the entries of all objects produced by the specified spread field */
+async function spreadObjects(runtime, input, field, scope) {
+  const values = await runtime.executeInstructions(field.value ?? [], [input], scope);
+
+  return runtime.muxOne([values], (output) => {
+    const value = runtime.unwrapValue(output);
+    const t = runtime.type(value);
+    if (t !== 'object') throw new JPLTypeError('cannot spread %s (%*<100v) into object', t, value);
+
+    return Object.entries(value);
+  });
+}
@@ -0,0 +1,22 @@
+import { JPLRegex } from '../../library';
+
+export default {
+  /** { string: string, flags: string, regex?: JPLRegex } */
+  op(runtime, input, params, scope, next) {
+    // Mapped params already contain the compiled regular expression
+    const regex = params.regex ?? new JPLRegex(params.string ?? '', params.flags ?? '');
+    return next(regex, scope);
+  },
+
+  /** { string: string, flags: string } */
+  map(runtime, params) {
+    const string = runtime.assertType(params.string, 'string');
+    const flags = runtime.assertType(params.flags, 'string');
+    return {
+      string,
+      flags,
+      // The regular expression is compiled once, so that it can be reused for all inputs
+      regex: new JPLRegex(string ?? '', flags ?? ''),
+    };
+  },
+};
@@ -0,0 +1,22 @@
+import { JPLTypeError } from '../../library';
+import { call } from './utils';
+
+export default {
+  /** { pipe: [op] } */
+  op(runtime, input, params, scope, next) {
+    return runtime.executeInstructions(params.pipe ?? [], [input], scope, (output) => {
+      const value = runtime.unwrapValue(output);
+      const t = runtime.type(value);
+      if (t !== 'array') throw new JPLTypeError('cannot spread %s (%*<100v) into array', t, value);
+
+      return runtime.muxAll([value], (item) => next(item, scope));
+    });
+  },
+
+  /** { pipe: function } */
+  map(runtime, params) {
+    return {
+      pipe: call(params.pipe),
+    };
+  },
+};
@@ -1,8 +1,36 @@
-import { JPLErrorEnclosure, JPLExecutionError } from '../../library';
+import { JPLErrorEnclosure, JPLExecutionError, errorInfo } from '../../library';
 import { call } from './utils';
 
+/**
+ * Handle the specified error by the first matching catch clause, which receives the structured error as its input.
+ * Raw clauses receive the error value instead, like catch clauses without a head do.
+ * If no clause matches, the error is rethrown.
+ */
+async function catchError(runtime, err, catches, scope, next) {
+  const info = errorInfo(err);
+  for (const clause of catches) {
+    if (clause.kinds?.length && !clause.kinds.includes(info.kind)) continue;
+    if (clause.when) {
+      // The clause matches if any of the outputs of its condition is truthy
+      const matches = await runtime.executeInstructions(clause.when, [info], scope);
+      if (!matches.some((m) => runtime.truthy(m))) continue;
+    }
+
+    return runtime.executeInstructions(
+      clause.pipe ?? [],
+      [clause.raw ? err.value : info],
+      scope,
+      (output) => next(output, scope),
+    );
+  }
+
+  throw err;
+}
+
 export default {
-  /** { try: [op], catch: [op] } */
+  /**
+   * { try: [op], catch: [op] } | { try: [op], catches: [{ kinds: [string], when: [op], raw: boolean, pipe: [op] }] }
+   */
   async op(runtime, input, params, scope, next) {
     const nextScope = scope.next({ signal: scope.signal.next() });
     try {
@@ -22,14 +50,31 @@ export default {
       if (JPLErrorEnclosure.is(err)) throw err.inner;
       if (!JPLExecutionError.is(err)) throw err;
       nextScope.signal.exit();
+      if (params.catches) return catchError(runtime, err, params.catches, scope, next);
       return runtime.executeInstructions(params.catch ?? [], [err.value], scope, (output) =>
         next(output, scope),
       );
     }
   },
 
-  /** { try: function, catch: function } */
+  /**
+   * { try: function, catch: function } | { try: function, catches: [{ kinds: [string], when: function, raw: boolean, pipe: function }] }
+   */
   map(runtime, params) {
+    if (params.catches) {
+      return {
+        try: call(params.try),
+        catches: runtime.muxOne([params.catches], (clause) => ({
+          kinds: runtime.muxOne([clause.kinds ?? []], (entry) =>
+            runtime.assertType(entry, 'string'),
+          ),
+          when: clause.when ? call(clause.when) : undefined,
+          raw: runtime.assertType(clause.raw ?? false, 'boolean'),
+          pipe: call(clause.pipe),
+        })),
+      };
+    }
+
     return {
       try: call(params.try),
       catch: call(params.catch),
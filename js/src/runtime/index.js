@@ -8,8 +8,10 @@ import {
   assertType,
   mux,
   muxAll,
+  muxAllSequential,
   muxAsync,
   muxOne,
+  muxSequential,
   normalize,
   stringify,
   strip,
@@ -70,8 +72,19 @@ class JPLRuntime {
     }
   };
 
+  /**
+   * Inherit a runtime that executes all multiplexed callbacks one after another.
+   * This is used for runtime areas that can be exited early, so that all outputs are produced in program order.
+   */
+  sequential = () => {
+    const runtime = Object.create(this);
+    runtime.muxAsync = muxSequential;
+    runtime.muxAll = muxAllSequential;
+    return runtime;
+  };
+
   /** Execute the specified instructions */
-  executeInstructions = (instructions, inputs, scope, next = (output) => [output]) => {
+  executeInstructions(instructions, inputs, scope, next = (output) => [output]) {
     const iter = async (from, input, currentScope) => {
       // Call stack decoupling - This is necessary as some browsers (i.e. Safari) have very limited call stack sizes which result in stack overflow exceptions in certain situations.
       await undefined;
@@ -90,7 +103,7 @@ class JPLRuntime {
     };
 
     return this.muxAll([inputs], (input) => iter(0, input, scope));
-  };
+  }
 
   /** Execute the specified OP */
   op(op, params, inputs, scope, next = (output) => [output]) {